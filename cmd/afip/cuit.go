@@ -0,0 +1,279 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lalloni/afip/cuit"
+)
+
+// cuitCommand is a subcommand of the cuit group.
+type cuitCommand struct {
+	help string
+	// each processes a single value returning the value to encode as JSON,
+	// the plain text output and whether the value could be processed
+	each func(value string) (interface{}, string, bool)
+}
+
+var cuitCommands = map[string]cuitCommand{
+	"validate": {"valida los valores informando el motivo por el que son inválidos", cuitValidate},
+	"format":   {"formatea los valores con la forma estándar DD-DDDDDDDD-D", cuitFormat},
+	"parse":    {"extrae los números de los valores informando sus partes", cuitParse},
+	"verifier": {"calcula el dígito verificador de los valores (de 10 u 11 dígitos)", cuitVerifier},
+	"next":     {"calcula el cuit válido siguiente a cada valor", cuitStep(cuit.Succ)},
+	"prev":     {"calcula el cuit válido anterior a cada valor", cuitStep(cuit.Pred)},
+	"tipo":     {"informa el tipo de persona que corresponde a cada valor", cuitTipo},
+	"random":   {"genera cuit válidos al azar (ver opciones con -h)", nil},
+}
+
+func cuitUsage() string {
+	names := make([]string, 0, len(cuitCommands))
+	for name := range cuitCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("uso: afip cuit <comando> [-json] [valores...]\n\ncomandos:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-9s %s\n", name, cuitCommands[name].help)
+	}
+	b.WriteString("\nsi no se especifican valores se leen desde la entrada estándar, uno por línea\n")
+	return b.String()
+}
+
+func runCUIT(args []string, e *env) int {
+	if len(args) == 0 {
+		fmt.Fprint(e.stderr, cuitUsage())
+		return exitUsage
+	}
+	name := args[0]
+	cmd, ok := cuitCommands[name]
+	if !ok {
+		fmt.Fprintf(e.stderr, "comando desconocido: %q\n\n%s", name, cuitUsage())
+		return exitUsage
+	}
+	flags := flag.NewFlagSet("afip cuit "+name, flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	asJSON := flags.Bool("json", false, "emite un objeto JSON por línea")
+	var n int
+	var seed int64
+	if name == "random" {
+		flags.IntVar(&n, "n", 1, "cantidad de cuit a generar")
+		flags.Int64Var(&seed, "seed", 0, "semilla para generar siempre los mismos cuit (0 usa la hora actual)")
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+	out := &output{env: e, json: *asJSON}
+	if name == "random" {
+		return cuitRandom(out, n, seed)
+	}
+	values := flags.Args()
+	if len(values) == 0 {
+		var err error
+		values, err = readValues(e)
+		if err != nil {
+			fmt.Fprintf(e.stderr, "error leyendo la entrada estándar: %v\n", err)
+			return exitInvalid
+		}
+	}
+	code := exitOK
+	for _, value := range values {
+		v, plain, ok := cmd.each(value)
+		if !ok {
+			code = exitInvalid
+		}
+		if err := out.emit(v, plain); err != nil {
+			fmt.Fprintf(e.stderr, "error escribiendo la salida: %v\n", err)
+			return exitInvalid
+		}
+	}
+	return code
+}
+
+func readValues(e *env) ([]string, error) {
+	var values []string
+	scanner := bufio.NewScanner(e.stdin)
+	for scanner.Scan() {
+		if v := strings.TrimSpace(scanner.Text()); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values, scanner.Err()
+}
+
+// output writes results as plain text or as JSON lines.
+type output struct {
+	*env
+	json bool
+}
+
+func (o *output) emit(v interface{}, plain string) error {
+	if o.json {
+		return json.NewEncoder(o.stdout).Encode(v)
+	}
+	_, err := fmt.Fprintln(o.stdout, plain)
+	return err
+}
+
+type cuitResult struct {
+	Input     string `json:"input,omitempty"`
+	CUIT      uint64 `json:"cuit,omitempty"`
+	Formatted string `json:"formatted,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func failed(value string, err error) (interface{}, string, bool) {
+	return cuitResult{Input: value, Error: err.Error()}, value + ": " + err.Error(), false
+}
+
+func parse(value string) (uint64, error) {
+	c, _, err := cuit.ParseLenient(value)
+	return c, err
+}
+
+func cuitValidate(value string) (interface{}, string, bool) {
+	type result struct {
+		cuitResult
+		Valid bool `json:"valid"`
+	}
+	c, err := parse(value)
+	if err == nil {
+		err = cuit.Validate(c)
+	}
+	if err != nil {
+		return result{cuitResult: cuitResult{Input: value, CUIT: c, Error: err.Error()}}, value + ": " + err.Error(), false
+	}
+	return result{cuitResult: cuitResult{Input: value, CUIT: c, Formatted: cuit.Format(c)}, Valid: true}, value + ": válido", true
+}
+
+func cuitFormat(value string) (interface{}, string, bool) {
+	c, err := parse(value)
+	if err != nil {
+		return failed(value, err)
+	}
+	f := cuit.Format(c)
+	return cuitResult{Input: value, CUIT: c, Formatted: f}, f, true
+}
+
+func cuitParse(value string) (interface{}, string, bool) {
+	type result struct {
+		cuitResult
+		Kind     uint64 `json:"kind"`
+		ID       uint64 `json:"id"`
+		Verifier uint64 `json:"verifier"`
+	}
+	c, err := parse(value)
+	if err != nil {
+		return failed(value, err)
+	}
+	kind, id, ver := cuit.Parts(c)
+	return result{cuitResult{Input: value, CUIT: c, Formatted: cuit.Format(c)}, kind, id, ver}, fmt.Sprint(c), true
+}
+
+func cuitVerifier(value string) (interface{}, string, bool) {
+	type result struct {
+		cuitResult
+		Verifier uint64 `json:"verifier"`
+	}
+	digits := strings.Replace(value, "-", "", -1)
+	if len(digits) == 10 {
+		digits += "0"
+	}
+	c, err := cuit.Parse(digits)
+	if err != nil {
+		return failed(value, err)
+	}
+	v := cuit.Verifier(c)
+	if v == 10 {
+		return failed(value, &cuit.NonexistentError{CUIT: c})
+	}
+	c = c - c%10 + v
+	return result{cuitResult{Input: value, CUIT: c, Formatted: cuit.Format(c)}, v}, fmt.Sprint(v), true
+}
+
+func cuitStep(step func(uint64) uint64) func(string) (interface{}, string, bool) {
+	return func(value string) (interface{}, string, bool) {
+		c, err := parse(value)
+		if err == nil {
+			err = cuit.Validate(c)
+		}
+		if err != nil {
+			return failed(value, err)
+		}
+		r := step(c)
+		f := cuit.Format(r)
+		return cuitResult{Input: value, CUIT: r, Formatted: f}, f, true
+	}
+}
+
+func cuitTipo(value string) (interface{}, string, bool) {
+	type result struct {
+		cuitResult
+		Tipo string `json:"tipo"`
+	}
+	c, err := parse(value)
+	if err == nil {
+		err = cuit.Validate(c)
+	}
+	if err != nil {
+		return failed(value, err)
+	}
+	t := cuit.TipoPersonaCUIT(c).String()
+	return result{cuitResult{Input: value, CUIT: c, Formatted: cuit.Format(c)}, t}, t, true
+}
+
+func cuitRandom(out *output, n int, seed int64) int {
+	if n < 0 {
+		fmt.Fprintln(out.stderr, "la cantidad debe ser positiva")
+		return exitUsage
+	}
+	var opts []cuit.Option
+	if seed != 0 {
+		opts = append(opts, cuit.WithSeed(seed))
+	}
+	g, err := cuit.NewGenerator(opts...)
+	if err != nil {
+		fmt.Fprintln(out.stderr, err)
+		return exitInvalid
+	}
+	for i := 0; i < n; i++ {
+		c, err := g.Next()
+		if err != nil {
+			fmt.Fprintln(out.stderr, err)
+			return exitInvalid
+		}
+		f := cuit.Format(c)
+		if err := out.emit(cuitResult{CUIT: c, Formatted: f}, f); err != nil {
+			fmt.Fprintf(out.stderr, "error escribiendo la salida: %v\n", err)
+			return exitInvalid
+		}
+	}
+	return exitOK
+}
@@ -0,0 +1,84 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string
+	}{
+		{"no group", nil, "", exitUsage, ""},
+		{"unknown group", []string{"foo"}, "", exitUsage, ""},
+		{"no command", []string{"cuit"}, "", exitUsage, ""},
+		{"unknown command", []string{"cuit", "foo"}, "", exitUsage, ""},
+		{"bad flag", []string{"cuit", "validate", "-foo"}, "", exitUsage, ""},
+		{"validate", []string{"cuit", "validate", "20242643772"}, "", exitOK, "20242643772: válido\n"},
+		{"validate invalid", []string{"cuit", "validate", "20242643772", "20242643773"}, "", exitInvalid,
+			"20242643772: válido\n20242643773: el dígito verificador del cuit/cuil 20-24264377-3 es incorrecto (debería ser 2)\n"},
+		{"validate stdin", []string{"cuit", "validate"}, "20242643772\n\n 33693450239 \n", exitOK, "20242643772: válido\n33693450239: válido\n"},
+		{"validate json", []string{"cuit", "validate", "-json", "20242643772", "x"}, "", exitInvalid,
+			`{"input":"20242643772","cuit":20242643772,"formatted":"20-24264377-2","valid":true}` + "\n" +
+				`{"input":"x","error":"formato incorrecto de cuit/cuil: \"x\"","valid":false}` + "\n"},
+		{"format", []string{"cuit", "format", "20242643772", "CUIT 20.24264377.2"}, "", exitOK, "20-24264377-2\n20-24264377-2\n"},
+		{"format invalid", []string{"cuit", "format", "2024264377"}, "", exitInvalid, "2024264377: formato incorrecto de cuit/cuil: \"2024264377\"\n"},
+		{"parse", []string{"cuit", "parse", "20-24264377-2"}, "", exitOK, "20242643772\n"},
+		{"parse json", []string{"cuit", "parse", "-json", "20-24264377-2"}, "", exitOK,
+			`{"input":"20-24264377-2","cuit":20242643772,"formatted":"20-24264377-2","kind":20,"id":24264377,"verifier":2}` + "\n"},
+		{"verifier", []string{"cuit", "verifier", "20-24264377", "20242643779"}, "", exitOK, "2\n2\n"},
+		{"verifier nonexistent", []string{"cuit", "verifier", "2000000001"}, "", exitInvalid,
+			"2000000001: no existe cuit/cuil con prefijo 20 y número 00000001\n"},
+		{"next", []string{"cuit", "next", "20242643772"}, "", exitOK, "20-24264378-0\n"},
+		{"prev", []string{"cuit", "prev", "20242643772"}, "", exitOK, "20-24264376-4\n"},
+		{"next invalid", []string{"cuit", "next", "20242643773"}, "", exitInvalid,
+			"20242643773: el dígito verificador del cuit/cuil 20-24264377-3 es incorrecto (debería ser 2)\n"},
+		{"tipo", []string{"cuit", "tipo", "20242643772", "33693450239"}, "", exitOK, "Persona Física\nPersona Jurídica\n"},
+		{"tipo json", []string{"cuit", "tipo", "-json", "33693450239"}, "", exitOK,
+			`{"input":"33693450239","cuit":33693450239,"formatted":"33-69345023-9","tipo":"Persona Jurídica"}` + "\n"},
+		{"random", []string{"cuit", "random", "-n", "3", "-seed", "1"}, "", exitOK, "24-82153551-0\n27-89785859-8\n24-49167320-2\n"},
+		{"random json", []string{"cuit", "random", "-json", "-seed", "1"}, "", exitOK, `{"cuit":24821535510,"formatted":"24-82153551-0"}` + "\n"},
+		{"random negative", []string{"cuit", "random", "-n", "-1"}, "", exitUsage, ""},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(test.args, &env{stdin: strings.NewReader(test.stdin), stdout: &stdout, stderr: &stderr})
+			assert.Equal(t, test.code, code, "stderr: %s", stderr.String())
+			assert.Equal(t, test.stdout, stdout.String())
+			if test.code == exitUsage {
+				assert.NotEmpty(t, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"runtime"
+	"strconv"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/lalloni/afip/cuit"
+)
+
+// Format is the format of the input.
+type Format uint8
+
+const (
+	// Lines is the format of inputs holding one value per line.
+	Lines Format = iota
+
+	// CSV is the format of comma separated values inputs (RFC 4180).
+	CSV
+)
+
+// Reason is the reason why a value was found valid or invalid.
+type Reason uint8
+
+const (
+	// Valid means the value is a valid CUIT number.
+	Valid Reason = iota
+
+	// BadFormat means the value could not be parsed (see cuit.FormatError).
+	BadFormat
+
+	// BadRange means the value has too many digits (see cuit.RangeError).
+	BadRange
+
+	// BadKind means the value has an unknown kind (see cuit.KindError).
+	BadKind
+
+	// BadVerifier means the value has an incorrect verifier digit (see
+	// cuit.VerifierError).
+	BadVerifier
+
+	// Nonexistent means the value can not exist (see cuit.NonexistentError).
+	Nonexistent
+
+	// MissingColumn means the CSV record has not got the configured column.
+	MissingColumn
+)
+
+var reasons = []string{"válido", "formato incorrecto", "fuera de rango", "tipo inválido", "verificador incorrecto", "inexistente", "columna faltante"}
+
+func (r Reason) String() string {
+	if int(r) < len(reasons) {
+		return reasons[r]
+	}
+	return "Reason(" + strconv.Itoa(int(r)) + ")"
+}
+
+// ReasonOf returns the Reason corresponding to an error returned by
+// cuit.Parse, cuit.ParseLenient or cuit.Validate.
+func ReasonOf(err error) Reason {
+	var (
+		ferr *cuit.FormatError
+		rerr *cuit.RangeError
+		kerr *cuit.KindError
+		verr *cuit.VerifierError
+		nerr *cuit.NonexistentError
+		cerr *ColumnError
+	)
+	switch {
+	case err == nil:
+		return Valid
+	case errors.As(err, &ferr):
+		return BadFormat
+	case errors.As(err, &rerr):
+		return BadRange
+	case errors.As(err, &kerr):
+		return BadKind
+	case errors.As(err, &verr):
+		return BadVerifier
+	case errors.As(err, &nerr):
+		return Nonexistent
+	case errors.As(err, &cerr):
+		return MissingColumn
+	default:
+		return BadFormat
+	}
+}
+
+// ColumnError is reported when a CSV record has not got the configured
+// column.
+type ColumnError struct {
+	Column  int
+	Columns int
+}
+
+func (e *ColumnError) Error() string {
+	return "el registro tiene " + strconv.Itoa(e.Columns) + " columnas y se esperaba la columna " + strconv.Itoa(e.Column+1)
+}
+
+// Config configures a validation run.
+type Config struct {
+	// Format is the format of the input.
+	Format Format
+
+	// Column is the zero based index of the column holding the values to
+	// validate in CSV inputs.
+	Column int
+
+	// Comma is the field delimiter of CSV inputs, ',' by default.
+	Comma rune
+
+	// Header makes the first line or record of the input to be skipped.
+	Header bool
+
+	// Lenient makes values to be parsed using cuit.ParseLenient instead of
+	// cuit.Parse.
+	Lenient bool
+
+	// Workers is the number of concurrent validations, runtime.NumCPU() by
+	// default.
+	Workers int
+
+	// Window is the maximum number of values in process at any time, which
+	// bounds the memory used, 1024 by default.
+	Window int
+}
+
+// Result is the result of validating a single value.
+type Result struct {
+	// Line is the line number of the value in the input. For CSV inputs it
+	// is the record number, which is the same unless some quoted field
+	// spans multiple lines.
+	Line int
+
+	// Raw is the value as found in the input.
+	Raw string
+
+	// CUIT is the parsed value, which is zero if it could not be parsed.
+	CUIT uint64
+
+	// Reason is the validation result.
+	Reason Reason
+
+	// Err is the validation error or nil if the value is valid.
+	Err error
+}
+
+// Summary holds statistics of a validation run.
+type Summary struct {
+	// Total is the number of values validated.
+	Total int
+
+	// Reasons holds the number of values found for each Reason.
+	Reasons map[Reason]int
+}
+
+// Valid returns the number of valid values.
+func (s Summary) Valid() int {
+	return s.Reasons[Valid]
+}
+
+// Invalid returns the number of invalid values.
+func (s Summary) Invalid() int {
+	return s.Total - s.Valid()
+}
+
+type job struct {
+	seq    int
+	line   int
+	raw    string
+	err    error
+	result Result
+}
+
+// Validate reads values from r as configured in cfg, validates them
+// concurrently and calls report with the result of each one in input order.
+//
+// It stops early returning an error when the input can not be read, when
+// report returns an error or when ctx is done. Otherwise it returns the
+// summary of the run.
+func Validate(ctx context.Context, r io.Reader, cfg Config, report func(Result) error) (Summary, error) {
+	if cfg.Workers < 1 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	if cfg.Window < 1 {
+		cfg.Window = 1024
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	window := make(chan struct{}, cfg.Window)
+	jobs := make(chan *job, cfg.Workers)
+	done := make(chan *job, cfg.Workers)
+	readerr := make(chan error, 1)
+
+	go func() {
+		defer close(jobs)
+		readerr <- read(r, cfg, func(j *job) bool {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return false
+			}
+			select {
+			case jobs <- j:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(cfg.Workers)
+	for i := 0; i < cfg.Workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if j.err != nil {
+					j.result = Result{Line: j.line, Raw: j.raw, Reason: ReasonOf(j.err), Err: j.err}
+				} else {
+					j.result = validate(j.line, j.raw, cfg.Lenient)
+				}
+				select {
+				case done <- j:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	summary := Summary{Reasons: map[Reason]int{}}
+	pending := map[int]*job{}
+	next := 0
+	for j := range done {
+		pending[j.seq] = j
+		for {
+			j, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			<-window
+			summary.Total++
+			summary.Reasons[j.result.Reason]++
+			if err := report(j.result); err != nil {
+				cancel()
+				return summary, err
+			}
+		}
+	}
+	if err := <-readerr; err != nil {
+		return summary, err
+	}
+	return summary, ctx.Err()
+}
+
+// read reads the values from r calling send for each one until it returns
+// false.
+func read(r io.Reader, cfg Config, send func(*job) bool) error {
+	seq := 0
+	emit := func(line int, raw string, err error) bool {
+		j := &job{seq: seq, line: line, raw: raw, err: err}
+		seq++
+		return send(j)
+	}
+	switch cfg.Format {
+	case Lines:
+		scanner := bufio.NewScanner(r)
+		for line := 1; scanner.Scan(); line++ {
+			if line == 1 && cfg.Header {
+				continue
+			}
+			if !emit(line, scanner.Text(), nil) {
+				return nil
+			}
+		}
+		return pkgerrors.Wrap(scanner.Err(), "leyendo líneas")
+	case CSV:
+		cr := csv.NewReader(r)
+		if cfg.Comma != 0 {
+			cr.Comma = cfg.Comma
+		}
+		cr.FieldsPerRecord = -1
+		cr.ReuseRecord = true
+		for line := 1; ; line++ {
+			record, err := cr.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return pkgerrors.Wrap(err, "leyendo registros csv")
+			}
+			if line == 1 && cfg.Header {
+				continue
+			}
+			var ok bool
+			if cfg.Column >= 0 && cfg.Column < len(record) {
+				ok = emit(line, record[cfg.Column], nil)
+			} else {
+				ok = emit(line, "", &ColumnError{Column: cfg.Column, Columns: len(record)})
+			}
+			if !ok {
+				return nil
+			}
+		}
+	default:
+		return pkgerrors.Errorf("formato desconocido: %d", cfg.Format)
+	}
+}
+
+func validate(line int, raw string, lenient bool) Result {
+	res := Result{Line: line, Raw: raw}
+	var (
+		c   uint64
+		err error
+	)
+	if lenient {
+		c, _, err = cuit.ParseLenient(raw)
+	} else {
+		c, err = cuit.Parse(raw)
+	}
+	if err == nil {
+		res.CUIT = c
+		err = cuit.Validate(c)
+	}
+	res.Err = err
+	res.Reason = ReasonOf(err)
+	return res
+}
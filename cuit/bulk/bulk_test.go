@@ -0,0 +1,146 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lalloni/afip/cuit"
+)
+
+func ExampleValidate() {
+	input := strings.NewReader("20-24264377-2\n20-24264377-3\n21-24264377-2\nnada\n")
+	summary, err := Validate(context.Background(), input, Config{}, func(r Result) error {
+		if r.Err != nil {
+			fmt.Printf("línea %d: %q: %v\n", r.Line, r.Raw, r.Err)
+		}
+		return nil
+	})
+	if err != nil {
+		// handle error
+	}
+	fmt.Println(summary.Total, summary.Valid(), summary.Invalid())
+	// Output:
+	// línea 2: "20-24264377-3": el dígito verificador del cuit/cuil 20-24264377-3 es incorrecto (debería ser 2)
+	// línea 3: "21-24264377-2": el prefijo 21 del cuit/cuil 21-24264377-2 no es válido (debe ser 20, 23, 24, 27, 30, 33 o 34)
+	// línea 4: "nada": formato incorrecto de cuit/cuil: "nada"
+	// 4 1 3
+}
+
+func collect(t *testing.T, input string, cfg Config) ([]Result, Summary) {
+	var results []Result
+	summary, err := Validate(context.Background(), strings.NewReader(input), cfg, func(r Result) error {
+		results = append(results, r)
+		return nil
+	})
+	assert.NoError(t, err)
+	return results, summary
+}
+
+func TestValidateCSV(t *testing.T) {
+	a := assert.New(t)
+	input := "nombre;cuit\nJuan;20-24264377-2\nAna;27240366180\nFoo\nBar;20000000015\nBaz; 20 24264377 2\n"
+	results, summary := collect(t, input, Config{Format: CSV, Comma: ';', Column: 1, Header: true})
+	a.Equal(5, summary.Total)
+	a.Equal(2, summary.Valid())
+	a.Equal(map[Reason]int{Valid: 2, MissingColumn: 1, Nonexistent: 1, BadFormat: 1}, summary.Reasons)
+	lines := []int{}
+	for _, r := range results {
+		lines = append(lines, r.Line)
+	}
+	a.Equal([]int{2, 3, 4, 5, 6}, lines)
+	a.Equal(uint64(20242643772), results[0].CUIT)
+	a.Equal(MissingColumn, results[2].Reason)
+	a.EqualError(results[2].Err, "el registro tiene 1 columnas y se esperaba la columna 2")
+
+	_, summary = collect(t, input, Config{Format: CSV, Comma: ';', Column: 1, Header: true, Lenient: true})
+	a.Equal(3, summary.Valid())
+}
+
+func TestValidateOrder(t *testing.T) {
+	a := assert.New(t)
+	rnd := rand.New(rand.NewSource(1))
+	var b strings.Builder
+	var want []Reason
+	for i := 0; i < 10000; i++ {
+		c := cuit.Random(rnd)
+		if rnd.Intn(2) == 0 {
+			c = cuit.Compose(c/1e9, c/10, c+1)
+			want = append(want, BadVerifier)
+		} else {
+			want = append(want, Valid)
+		}
+		fmt.Fprintln(&b, cuit.Format(c))
+	}
+	results, summary := collect(t, b.String(), Config{Workers: 8, Window: 16})
+	a.Equal(10000, summary.Total)
+	for i, r := range results {
+		a.Equal(i+1, r.Line)
+		a.Equal(want[i], r.Reason, "line %d", r.Line)
+	}
+}
+
+func TestValidateStop(t *testing.T) {
+	a := assert.New(t)
+	input := strings.Repeat("20-24264377-2\n", 10000)
+	stop := errors.New("stop")
+	count := 0
+	summary, err := Validate(context.Background(), strings.NewReader(input), Config{Window: 8}, func(r Result) error {
+		count++
+		if count == 100 {
+			return stop
+		}
+		return nil
+	})
+	a.Equal(stop, err)
+	a.Equal(100, summary.Total)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = Validate(ctx, strings.NewReader(input), Config{Window: 8}, func(r Result) error {
+		cancel()
+		return nil
+	})
+	a.Equal(context.Canceled, err)
+}
+
+func TestValidateErrors(t *testing.T) {
+	_, err := Validate(context.Background(), strings.NewReader("a,\"b\n"), Config{Format: CSV}, func(Result) error { return nil })
+	assert.Error(t, err)
+	_, err = Validate(context.Background(), strings.NewReader(""), Config{Format: Format(9)}, func(Result) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestReason(t *testing.T) {
+	assert.Equal(t, BadRange, ReasonOf(cuit.Validate(1e12)))
+	assert.Equal(t, BadKind, ReasonOf(cuit.Validate(21242643772)))
+	assert.Equal(t, BadFormat, ReasonOf(errors.New("otro")))
+	assert.Equal(t, "verificador incorrecto", BadVerifier.String())
+	assert.Equal(t, "Reason(20)", Reason(20).String())
+}
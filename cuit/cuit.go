@@ -27,8 +27,6 @@ import (
 	"math/rand"
 	"regexp"
 	"strconv"
-
-	"github.com/pkg/errors"
 )
 
 const (
@@ -101,11 +99,12 @@ func Verifier(cuit uint64) uint64 {
 // standard format "DD-DDDDDDDD-D" being "D" any decimal digit and
 // both "-" characters optional.
 //
-// If the string can not be parsed as a CUIT number the function returns error.
+// If the string can not be parsed as a CUIT number the function returns a
+// *FormatError.
 func Parse(cuit string) (uint64, error) {
 	match := pattern.FindStringSubmatch(cuit)
 	if match == nil {
-		return 0, errors.Errorf("formato incorrecto de cuit/cuil: %q", cuit)
+		return 0, &FormatError{Input: cuit}
 	}
 	// the following three errors can never
 	// happen because the regexp pattern
@@ -142,17 +141,7 @@ func Format(cuit uint64) string {
 func Random(r *rand.Rand) uint64 {
 	k := legkinds[r.Intn(len(legkinds))]
 	id := r.Uint64() % 1e8
-	c := Compose(k, id, 0)
-	v := Verifier(c)
-	if v == 10 {
-		if k < 30 {
-			c = Compose(23, id, 0)
-		} else {
-			c = Compose(33, id, 0)
-		}
-		v = Verifier(c)
-	}
-	return c + v
+	return compose(k, id)
 }
 
 // Compose builds a cuit number from its parts.
@@ -0,0 +1,84 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import "github.com/pkg/errors"
+
+// FromDNI computes the CUIL number that corresponds to the provided DNI
+// number and declared sex following the AFIP rule: kind 20 for SexoMasculino,
+// 27 for SexoFemenino and, when the verifier digit for that kind would be
+// 10, kind 23 instead.
+//
+// SexoNoBinario is handled as SexoMasculino (kind 20 falling back to 23),
+// which is the kind assigned to DNI numbers with sex "X".
+//
+// It returns an error if dni is zero or has more than eight digits or if
+// sexo is unknown.
+func FromDNI(dni uint64, sexo Sexo) (uint64, error) {
+	if dni == 0 || dni > 99999999 {
+		return 0, errors.Errorf("número de dni inválido: %d", dni)
+	}
+	var kind uint64
+	switch sexo {
+	case SexoMasculino, SexoNoBinario:
+		kind = 20
+	case SexoFemenino:
+		kind = 27
+	default:
+		return 0, errors.Errorf("sexo desconocido: %v", sexo)
+	}
+	return compose(kind, dni), nil
+}
+
+// DNI extracts the DNI number out of the provided CUIT/CUIL number.
+//
+// It returns an error if cuit is not valid (see Validate) or if it belongs
+// to a PersonaJurídica.
+func DNI(cuit uint64) (uint64, error) {
+	if err := Validate(cuit); err != nil {
+		return 0, err
+	}
+	if TipoPersonaCUIT(cuit) != PersonaFísica {
+		return 0, errors.Errorf("el cuit %s no corresponde a una persona física", Format(cuit))
+	}
+	_, id, _ := Parts(cuit)
+	return id, nil
+}
+
+// compose builds a valid cuit number from the provided kind and id computing
+// its verifier digit and, when there is no possible verifier digit for that
+// kind, switching it to the fallback kind (23 for personas físicas and 33
+// for personas jurídicas).
+func compose(kind, id uint64) uint64 {
+	c := Compose(kind, id, 0)
+	v := Verifier(c)
+	if v == 10 {
+		if kind < 30 {
+			c = Compose(23, id, 0)
+		} else {
+			c = Compose(33, id, 0)
+		}
+		v = Verifier(c)
+	}
+	return c + v
+}
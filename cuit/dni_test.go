@@ -0,0 +1,108 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleFromDNI() {
+	cuil, err := FromDNI(24264377, SexoMasculino)
+	if err != nil {
+		// handle error
+	}
+	fmt.Println(Format(cuil))
+	// Output: 20-24264377-2
+}
+
+func ExampleDNI() {
+	dni, err := DNI(27240366180)
+	if err != nil {
+		// handle error
+	}
+	fmt.Println(dni)
+	// Output: 24036618
+}
+
+func TestFromDNI(t *testing.T) {
+	tests := []struct {
+		name    string
+		dni     uint64
+		sexo    Sexo
+		want    uint64
+		wantErr bool
+	}{
+		{"male", 24264377, SexoMasculino, 20242643772, false},
+		{"female", 24036618, SexoFemenino, 27240366180, false},
+		{"non binary", 24264377, SexoNoBinario, 20242643772, false},
+		{"male fallback", 1, SexoMasculino, 23000000019, false},
+		{"female fallback", 9, SexoFemenino, 23000000094, false},
+		{"zero dni", 0, SexoMasculino, 0, true},
+		{"big dni", 100000000, SexoMasculino, 0, true},
+		{"unknown sexo", 24264377, Sexo(9), 0, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := FromDNI(test.dni, test.sexo)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, IsValid(got))
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+	t.Run("roundtrip", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(1))
+		for i := 0; i < 10000; i++ {
+			dni := rnd.Uint64()%99999999 + 1
+			sexo := Sexo(rnd.Intn(3))
+			cuil, err := FromDNI(dni, sexo)
+			assert.NoError(t, err)
+			got, err := DNI(cuil)
+			assert.NoError(t, err)
+			assert.Equal(t, dni, got)
+		}
+	})
+}
+
+func TestDNI(t *testing.T) {
+	a := assert.New(t)
+	_, err := DNI(20242643773)
+	a.Error(err)
+	_, err = DNI(33693450239)
+	a.Error(err)
+}
+
+func TestSexoString(t *testing.T) {
+	assert.Equal(t, "Masculino", SexoMasculino.String())
+	assert.Equal(t, "Femenino", SexoFemenino.String())
+	assert.Equal(t, "No Binario", SexoNoBinario.String())
+	assert.Equal(t, "Sexo(9)", Sexo(9).String())
+}
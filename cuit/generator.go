@@ -0,0 +1,210 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxAttempts limits how many consecutive numbers a Generator draws before
+// giving up on finding a new one.
+const maxAttempts = 10000
+
+// Generator generates random valid CUIT numbers according to the options
+// provided to NewGenerator.
+//
+// A Generator is not safe for concurrent use.
+type Generator struct {
+	rand   *rand.Rand
+	kinds  []uint64
+	minID  uint64
+	maxID  uint64
+	unique bool
+	seen   map[uint64]bool
+}
+
+// Option configures a Generator.
+type Option func(*Generator) error
+
+// NewGenerator creates a Generator configured with the provided options.
+//
+// By default it behaves like Random, picking kinds 20, 24, 27, 30 or 34 and
+// any identifier, using a source of random numbers seeded with the current
+// time.
+func NewGenerator(opts ...Option) (*Generator, error) {
+	g := &Generator{
+		kinds: legkinds,
+		maxID: 99999999,
+	}
+	for _, opt := range opts {
+		if err := opt(g); err != nil {
+			return nil, err
+		}
+	}
+	if g.rand == nil {
+		g.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return g, nil
+}
+
+// WithKinds restricts the kinds of the generated numbers.
+//
+// As it happens with Random and FromDNI, when a kind has no possible
+// verifier digit for a given identifier the number is generated using the
+// fallback kind (23 for personas físicas and 33 for personas jurídicas).
+func WithKinds(kinds ...uint64) Option {
+	return func(g *Generator) error {
+		if len(kinds) == 0 {
+			return errors.New("no se especificaron tipos de cuit")
+		}
+		for _, kind := range kinds {
+			if kind > 99 || !validKind(Compose(kind, 0, 0)) {
+				return errors.Errorf("tipo de cuit inválido: %d", kind)
+			}
+		}
+		g.kinds = append([]uint64(nil), kinds...)
+		return nil
+	}
+}
+
+// WithTipoPersona restricts the generated numbers to those belonging to the
+// provided TipoPersona.
+func WithTipoPersona(t TipoPersona) Option {
+	return func(g *Generator) error {
+		switch t {
+		case PersonaFísica:
+			g.kinds = []uint64{20, 24, 27}
+		case PersonaJurídica:
+			g.kinds = []uint64{30, 34}
+		default:
+			return errors.Errorf("tipo de persona desconocido: %v", t)
+		}
+		return nil
+	}
+}
+
+// WithSexo restricts the generated numbers to CUILs of persons of the
+// provided Sexo as computed by FromDNI.
+func WithSexo(s Sexo) Option {
+	return func(g *Generator) error {
+		switch s {
+		case SexoMasculino, SexoNoBinario:
+			g.kinds = []uint64{20}
+		case SexoFemenino:
+			g.kinds = []uint64{27}
+		default:
+			return errors.Errorf("sexo desconocido: %v", s)
+		}
+		return nil
+	}
+}
+
+// WithIDRange restricts the identifier part of the generated numbers to
+// [min, max].
+func WithIDRange(min, max uint64) Option {
+	return func(g *Generator) error {
+		if min > max || max > 99999999 {
+			return errors.Errorf("rango de identificadores inválido: [%d, %d]", min, max)
+		}
+		g.minID, g.maxID = min, max
+		return nil
+	}
+}
+
+// WithUnique makes the Generator never return the same number twice.
+func WithUnique() Option {
+	return func(g *Generator) error {
+		g.unique = true
+		g.seen = map[uint64]bool{}
+		return nil
+	}
+}
+
+// WithSeed makes the Generator deterministic using a source of random
+// numbers seeded with seed.
+func WithSeed(seed int64) Option {
+	return WithRand(rand.New(rand.NewSource(seed)))
+}
+
+// WithRand makes the Generator use r as its source of random numbers.
+func WithRand(r *rand.Rand) Option {
+	return func(g *Generator) error {
+		if r == nil {
+			return errors.New("fuente de números aleatorios nula")
+		}
+		g.rand = r
+		return nil
+	}
+}
+
+// Next returns a new random valid CUIT number.
+//
+// It returns an error when the Generator is configured with WithUnique and
+// it could not find a number not generated before.
+func (g *Generator) Next() (uint64, error) {
+	for i := 0; i < maxAttempts; i++ {
+		kind := g.kinds[g.rand.Intn(len(g.kinds))]
+		id := g.minID + g.rand.Uint64()%(g.maxID-g.minID+1)
+		c := compose(kind, id)
+		if !IsValid(c) {
+			// kinds 23 and 33 have no fallback
+			continue
+		}
+		if g.unique {
+			if g.seen[c] {
+				continue
+			}
+			g.seen[c] = true
+		}
+		return c, nil
+	}
+	return 0, errors.Errorf("no fue posible generar un cuit nuevo luego de %d intentos", maxAttempts)
+}
+
+// Take returns n new random valid CUIT numbers.
+//
+// It returns an error when n is negative or when Next fails.
+func (g *Generator) Take(n int) ([]uint64, error) {
+	if n < 0 {
+		return nil, errors.Errorf("la cantidad de cuit a generar no puede ser negativa: %d", n)
+	}
+	cs := make([]uint64, n)
+	for i := range cs {
+		c, err := g.Next()
+		if err != nil {
+			return nil, err
+		}
+		cs[i] = c
+	}
+	return cs, nil
+}
+
+// Generate implements testing/quick.Generator producing random valid CUIT
+// values using Random.
+func (CUIT) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(CUIT(Random(r)))
+}
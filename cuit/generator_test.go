@@ -0,0 +1,169 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"fmt"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleGenerator() {
+	g, err := NewGenerator(
+		WithSeed(1),
+		WithSexo(SexoFemenino),
+		WithIDRange(10000000, 45000000),
+		WithUnique(),
+	)
+	if err != nil {
+		// handle error
+	}
+	cs, err := g.Take(3)
+	if err != nil {
+		// handle error
+	}
+	for _, c := range cs {
+		fmt.Println(Format(c))
+	}
+	// Output:
+	// 27-44582831-4
+	// 27-36287225-7
+	// 23-34180362-4
+}
+
+func TestGenerator(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		check func(uint64) bool
+	}{
+		{"default", nil, func(c uint64) bool { return true }},
+		{"legal", []Option{WithTipoPersona(PersonaJurídica)}, func(c uint64) bool {
+			return TipoPersonaCUIT(c) == PersonaJurídica
+		}},
+		{"physical", []Option{WithTipoPersona(PersonaFísica)}, func(c uint64) bool {
+			return TipoPersonaCUIT(c) == PersonaFísica
+		}},
+		{"women", []Option{WithSexo(SexoFemenino)}, func(c uint64) bool {
+			k, _, _ := Parts(c)
+			return k == 27 || k == 23
+		}},
+		{"kinds", []Option{WithKinds(23, 33)}, func(c uint64) bool {
+			k, _, _ := Parts(c)
+			return k == 23 || k == 33
+		}},
+		{"ids", []Option{WithIDRange(10000000, 10000100)}, func(c uint64) bool {
+			_, id, _ := Parts(c)
+			return id >= 10000000 && id <= 10000100
+		}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			g, err := NewGenerator(append(test.opts, WithSeed(1))...)
+			assert.NoError(t, err)
+			for i := 0; i < 1000; i++ {
+				c, err := g.Next()
+				assert.NoError(t, err)
+				assert.True(t, IsValid(c), "invalid %d", c)
+				assert.True(t, test.check(c), "unexpected %d", c)
+			}
+		})
+	}
+}
+
+func TestGeneratorDeterministic(t *testing.T) {
+	g1, _ := NewGenerator(WithSeed(42))
+	g2, _ := NewGenerator(WithSeed(42))
+	cs1, err := g1.Take(100)
+	assert.NoError(t, err)
+	cs2, err := g2.Take(100)
+	assert.NoError(t, err)
+	assert.Equal(t, cs1, cs2)
+}
+
+func TestGeneratorUnique(t *testing.T) {
+	a := assert.New(t)
+	g, err := NewGenerator(WithSeed(1), WithKinds(20), WithIDRange(0, 99), WithUnique())
+	a.NoError(err)
+	cs, err := g.Take(100)
+	a.NoError(err, "there are exactly 100 cuits with kinds 20 or 23 and these ids")
+	seen := map[uint64]bool{}
+	for _, c := range cs {
+		a.False(seen[c], "repeated %d", c)
+		seen[c] = true
+	}
+	_, err = g.Next()
+	a.Error(err, "exhausted")
+}
+
+func TestGeneratorKindsCopied(t *testing.T) {
+	kinds := []uint64{20}
+	g, err := NewGenerator(WithSeed(1), WithKinds(kinds...))
+	assert.NoError(t, err)
+	kinds[0] = 30
+	cs, err := g.Take(50)
+	assert.NoError(t, err)
+	for _, c := range cs {
+		kind, _, _ := Parts(c)
+		assert.Contains(t, []uint64{20, 23}, kind)
+	}
+}
+
+func TestGeneratorTake(t *testing.T) {
+	g, _ := NewGenerator(WithSeed(1))
+	cs, err := g.Take(0)
+	assert.NoError(t, err)
+	assert.Empty(t, cs)
+	cs, err = g.Take(-1)
+	assert.EqualError(t, err, "la cantidad de cuit a generar no puede ser negativa: -1")
+	assert.Nil(t, cs)
+}
+
+func TestGeneratorOptions(t *testing.T) {
+	bad := []Option{
+		WithKinds(),
+		WithKinds(21),
+		WithKinds(120),
+		WithTipoPersona(TipoPersona(9)),
+		WithSexo(Sexo(9)),
+		WithIDRange(10, 5),
+		WithIDRange(0, 1e8),
+		WithRand(nil),
+	}
+	for _, opt := range bad {
+		_, err := NewGenerator(opt)
+		assert.Error(t, err)
+	}
+}
+
+func TestCUITGenerate(t *testing.T) {
+	f := func(c CUIT) bool {
+		return c.IsValid()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,191 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Normalization is a set of the normalizations applied by ParseLenient to
+// its input.
+type Normalization uint8
+
+const (
+	// TrimmedSpace means leading or trailing white space was removed.
+	TrimmedSpace Normalization = 1 << iota
+
+	// RemovedPrefix means a leading label like "CUIT:" or "CUIL Nº" was
+	// removed.
+	RemovedPrefix
+
+	// ConvertedDigits means full-width digits were converted to ASCII.
+	ConvertedDigits
+
+	// ReplacedSeparators means separators other than a single "-" (like
+	// spaces, dots, slashes or en-dashes) were found between digits.
+	ReplacedSeparators
+
+	// PaddedID means the identifier part had less than eight digits and was
+	// padded with zeroes.
+	PaddedID
+)
+
+var normalizationNames = []string{"TrimmedSpace", "RemovedPrefix", "ConvertedDigits", "ReplacedSeparators", "PaddedID"}
+
+// Has reports whether all normalizations in o are included in n.
+func (n Normalization) Has(o Normalization) bool {
+	return n&o == o
+}
+
+func (n Normalization) String() string {
+	if n == 0 {
+		return "None"
+	}
+	var names []string
+	for i, name := range normalizationNames {
+		if n.Has(1 << uint(i)) {
+			names = append(names, name)
+			n &^= 1 << uint(i)
+		}
+	}
+	if n != 0 {
+		names = append(names, "Normalization(0x"+strconv.FormatUint(uint64(n), 16)+")")
+	}
+	return strings.Join(names, "|")
+}
+
+var lenientPrefix = regexp.MustCompile(`^(?i)(cuit|cuil|cdi)\s*(n\s*[º°o]\.?|nro\.?|#)?\s*:?\s*`)
+
+// ParseLenient extracts a CUIT number from the string provided like Parse
+// does but normalizing the variants usually found in real world data:
+//
+//   - leading and trailing white space
+//   - a leading "CUIT", "CUIL" or "CDI" label optionally followed by "Nº" and ":"
+//   - full-width digits
+//   - spaces, dots, slashes and dashes of any kind as separators
+//   - dots as thousands separators in the identifier part
+//   - identifier parts with less than eight digits when explicitly separated
+//     from the kind and verifier parts
+//
+// It also returns the set of normalizations applied.
+//
+// As it happens with Parse, the returned number is not validated (see
+// Validate). Input which could be read in more than one way (for example
+// separators in unexpected places or less than eleven digits without
+// separators) is rejected returning a *FormatError.
+func ParseLenient(cuit string) (uint64, Normalization, error) {
+	var norm Normalization
+	s := strings.TrimSpace(cuit)
+	if s != cuit {
+		norm |= TrimmedSpace
+	}
+	if loc := lenientPrefix.FindStringIndex(s); loc != nil {
+		s = s[loc[1]:]
+		norm |= RemovedPrefix
+	}
+	var groups []string
+	var group []byte
+	sep := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9', r >= '０' && r <= '９':
+			if sep != "" {
+				if sep != "-" {
+					norm |= ReplacedSeparators
+				}
+				groups = append(groups, string(group))
+				group, sep = nil, ""
+			}
+			if r > '9' {
+				r = r - '０' + '0'
+				norm |= ConvertedDigits
+			}
+			group = append(group, byte(r))
+		case isSeparator(r) && len(group) > 0:
+			sep += string(r)
+		default:
+			return 0, 0, &FormatError{Input: cuit}
+		}
+	}
+	if len(group) == 0 || sep != "" {
+		return 0, 0, &FormatError{Input: cuit}
+	}
+	groups = append(groups, string(group))
+	kind, id, ver, ok := lenientParts(groups)
+	if !ok {
+		return 0, 0, &FormatError{Input: cuit}
+	}
+	if len(id) < 8 {
+		norm |= PaddedID
+	}
+	k, _ := strconv.ParseUint(kind, 10, 64)
+	i, _ := strconv.ParseUint(id, 10, 64)
+	v, _ := strconv.ParseUint(ver, 10, 64)
+	return Compose(k, i, v), norm, nil
+}
+
+func isSeparator(r rune) bool {
+	switch r {
+	case '-', '.', '/', '‐', '‑', '‒', '–', '—', '−', '－', '．', '／':
+		return true
+	}
+	return unicode.IsSpace(r)
+}
+
+// lenientParts splits the digit groups found by ParseLenient into the kind,
+// identifier and verifier parts, reporting whether that could be done
+// unambiguously.
+func lenientParts(groups []string) (kind, id, ver string, ok bool) {
+	n := len(groups)
+	switch {
+	case n == 1 && len(groups[0]) == 11:
+		return groups[0][:2], groups[0][2:10], groups[0][10:], true
+	case n == 2 && len(groups[0]) == 2 && len(groups[1]) == 9:
+		return groups[0], groups[1][:8], groups[1][8:], true
+	case n == 2 && len(groups[0]) == 10 && len(groups[1]) == 1:
+		return groups[0][:2], groups[0][2:], groups[1], true
+	case n >= 3 && len(groups[0]) == 2 && len(groups[n-1]) == 1:
+		id, ok := lenientID(groups[1 : n-1])
+		return groups[0], id, groups[n-1], ok
+	}
+	return "", "", "", false
+}
+
+// lenientID joins the identifier groups accepting either a single group of
+// up to eight digits or up to three digits followed by groups of exactly
+// three digits (as in "24.264.377").
+func lenientID(groups []string) (string, bool) {
+	if len(groups[0]) > 3 && len(groups) > 1 {
+		return "", false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return "", false
+		}
+	}
+	id := strings.Join(groups, "")
+	return id, len(id) <= 8
+}
@@ -0,0 +1,101 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseLenient() {
+	cuit, norm, err := ParseLenient(" CUIT: 20.24.264.377/2 ")
+	if err != nil {
+		// handle parse error
+	}
+	fmt.Println(cuit)
+	fmt.Println(norm)
+	// Output:
+	// 20242643772
+	// TrimmedSpace|RemovedPrefix|ReplacedSeparators
+}
+
+func TestParseLenient(t *testing.T) {
+	tests := []struct {
+		name     string
+		cuit     string
+		want     uint64
+		wantNorm Normalization
+		wantErr  bool
+	}{
+		{"standard", "20-24264377-2", 20242643772, 0, false},
+		{"no dashes", "20242643772", 20242643772, 0, false},
+		{"one dash", "20-242643772", 20242643772, 0, false},
+		{"other dash", "2024264377-2", 20242643772, 0, false},
+		{"spaces", "20 24264377 2", 20242643772, ReplacedSeparators, false},
+		{"many spaces", "20   24264377  2", 20242643772, ReplacedSeparators, false},
+		{"dots", "20.24264377.2", 20242643772, ReplacedSeparators, false},
+		{"slashes", "20/24264377/2", 20242643772, ReplacedSeparators, false},
+		{"en-dashes", "20–24264377–2", 20242643772, ReplacedSeparators, false},
+		{"thousands", "20-24.264.377-2", 20242643772, ReplacedSeparators, false},
+		{"surrounding space", "\t20-24264377-2 \n", 20242643772, TrimmedSpace, false},
+		{"cuit prefix", "CUIT: 20-24264377-2", 20242643772, RemovedPrefix, false},
+		{"cuil prefix", "cuil 20-24264377-2", 20242643772, RemovedPrefix, false},
+		{"number prefix", "CUIT Nº 20-24264377-2", 20242643772, RemovedPrefix, false},
+		{"full-width", "２０-２４２６４３７７-２", 20242643772, ConvertedDigits, false},
+		{"short id", "20-4264377-2", 20042643772, PaddedID, false},
+		{"short id thousands", "20-4.264.377-2", 20042643772, PaddedID | ReplacedSeparators, false},
+		{"empty", "", 0, 0, true},
+		{"only prefix", "CUIT:", 0, 0, true},
+		{"short without separators", "2042643772", 0, 0, true},
+		{"long without separators", "202426437721", 0, 0, true},
+		{"misplaced separators", "202-4264377-2", 0, 0, true},
+		{"bad thousands", "20-24.26.4377-2", 0, 0, true},
+		{"long id", "20-242643771-2", 0, 0, true},
+		{"leading separator", "-20-24264377-2", 0, 0, true},
+		{"trailing separator", "20-24264377-2-", 0, 0, true},
+		{"letters", "20-2426437x-2", 0, 0, true},
+		{"two numbers", "20-24264377-2 20-24264377-2", 0, 0, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, norm, err := ParseLenient(test.cuit)
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.IsType(t, &FormatError{}, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, test.wantNorm, norm)
+		})
+	}
+}
+
+func TestNormalizationString(t *testing.T) {
+	assert.Equal(t, "None", Normalization(0).String())
+	assert.Equal(t, "ConvertedDigits|PaddedID", (ConvertedDigits | PaddedID).String())
+	assert.Equal(t, "TrimmedSpace|Normalization(0x80)", (TrimmedSpace | 0x80).String())
+}
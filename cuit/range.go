@@ -0,0 +1,264 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+// Span is a closed interval of CUIT numbers [From, To].
+//
+// A Span with From greater than To is empty.
+type Span struct {
+	From uint64
+	To   uint64
+}
+
+// KindSpan returns the Span covering every CUIT number with the provided
+// kind prefix.
+func KindSpan(kind uint64) Span {
+	return Span{From: Compose(kind, 0, 0), To: Compose(kind, 99999999, 9)}
+}
+
+// Range returns an Iterator over the valid CUIT numbers in [from, to].
+func Range(from, to uint64) *Iterator {
+	return Span{From: from, To: to}.Iter()
+}
+
+// Count computes the number of valid CUIT numbers in [from, to] without
+// iterating over them.
+func Count(from, to uint64) uint64 {
+	return Span{From: from, To: to}.Count()
+}
+
+// Iter returns an Iterator over the valid CUIT numbers in s.
+func (s Span) Iter() *Iterator {
+	return &Iterator{next: s.From, to: s.To}
+}
+
+// Count computes the number of valid CUIT numbers in s without iterating
+// over them.
+func (s Span) Count() uint64 {
+	var n uint64
+	for _, kind := range allkinds {
+		ks := KindSpan(kind)
+		lo, hi := max(s.From, ks.From), min(s.To, ks.To)
+		if lo > hi {
+			continue
+		}
+		_, idlo, _ := Parts(lo)
+		_, idhi, _ := Parts(hi)
+		n += existing(kind, idlo, idhi)
+		// the ids at both ends may have their only valid cuit out of s
+		if c, ok := valid(kind, idlo); ok && c < lo {
+			n--
+		}
+		if c, ok := valid(kind, idhi); ok && c > hi {
+			n--
+		}
+	}
+	return n
+}
+
+// Split divides s in n contiguous spans containing (as far as possible) the
+// same number of valid CUIT numbers each, which is useful for distributing
+// work among parallel workers.
+//
+// Some of the returned spans may be empty when s contains less than n valid
+// numbers and, when it does not contain any, s is returned as the only span.
+// It returns nil when n is less than one.
+func (s Span) Split(n int) []Span {
+	if n < 1 {
+		return nil
+	}
+	total := s.Count()
+	if total == 0 {
+		return []Span{s}
+	}
+	spans := make([]Span, 0, n)
+	from := s.From
+	for i := 1; i < n; i++ {
+		target := total * uint64(i) / uint64(n)
+		// binary search the first valid number past the target ones
+		lo, hi := s.From, s.To
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			if Count(s.From, mid) > target {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		spans = append(spans, Span{From: from, To: lo - 1})
+		from = lo
+	}
+	return append(spans, Span{From: from, To: s.To})
+}
+
+// Iterator iterates over the valid CUIT numbers of a Span in ascending
+// order.
+//
+// Its usage is similar to bufio.Scanner:
+//
+//	it := cuit.Range(from, to)
+//	for it.Next() {
+//		c := it.CUIT()
+//		...
+//	}
+type Iterator struct {
+	next uint64
+	to   uint64
+	cur  uint64
+	done bool
+}
+
+// Next advances the iterator to the next valid CUIT number, returning false
+// when there are no more.
+func (it *Iterator) Next() bool {
+	if it.done {
+		return false
+	}
+	c, ok := nextValid(it.next)
+	if !ok || c > it.to {
+		it.done = true
+		it.cur = 0
+		return false
+	}
+	it.cur = c
+	if c == Max {
+		it.done = true
+	} else {
+		it.next = c + 1
+	}
+	return true
+}
+
+// CUIT returns the current CUIT number.
+func (it *Iterator) CUIT() uint64 {
+	return it.cur
+}
+
+// nextValid returns the smallest valid cuit greater than or equal to c.
+func nextValid(c uint64) (uint64, bool) {
+	for c <= Max {
+		kind, id, _ := Parts(c)
+		if !validKind(c) {
+			next, ok := nextKind(kind)
+			if !ok {
+				return 0, false
+			}
+			c = Compose(next, 0, 0)
+			continue
+		}
+		if v, ok := valid(kind, id); ok && v >= c {
+			return v, true
+		}
+		c = Compose(kind, id, 0) + 10
+	}
+	return 0, false
+}
+
+// nextKind returns the smallest valid kind greater than kind.
+func nextKind(kind uint64) (uint64, bool) {
+	for _, k := range allkinds {
+		if k > kind {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// valid returns the valid cuit with the provided kind and id, if it exists.
+func valid(kind, id uint64) (uint64, bool) {
+	c := Compose(kind, id, 0)
+	v := Verifier(c)
+	return c + v, v < 10
+}
+
+// existing counts the ids in [lo, hi] that have a valid cuit with the
+// provided kind, that is, whose weighted digit sum (see Verifier) is not
+// congruent with 1 modulo 11.
+func existing(kind, lo, hi uint64) uint64 {
+	if lo > hi {
+		return 0
+	}
+	// the kind digits are weighted by the factors following the id ones
+	ksum := factor[8%factors]*(kind%10) + factor[9%factors]*(kind/10%10)
+	target := (1 + 11 - ksum%11) % 11
+	bad := nonexistent(hi, target)
+	if lo > 0 {
+		bad -= nonexistent(lo-1, target)
+	}
+	return hi - lo + 1 - bad
+}
+
+// idsums[p][m] holds how many combinations of the p least significant id
+// digits have a weighted sum congruent with m modulo 11.
+var idsums = func() (sums [9][11]uint64) {
+	sums[0][0] = 1
+	for p := 1; p < len(sums); p++ {
+		w := factor[(p-1)%factors]
+		for m := uint64(0); m < 11; m++ {
+			for d := uint64(0); d < 10; d++ {
+				sums[p][(m+w*d)%11] += sums[p-1][m]
+			}
+		}
+	}
+	return sums
+}()
+
+// nonexistent counts the ids in [0, n] whose weighted digit sum is congruent
+// with target modulo 11.
+func nonexistent(n, target uint64) uint64 {
+	var count, prefix uint64
+	for p := 8; p > 0; p-- {
+		w := factor[(p-1)%factors]
+		digit := n / pow10(p-1) % 10
+		for d := uint64(0); d < digit; d++ {
+			count += idsums[p-1][(target+11*11-prefix-w*d)%11]
+		}
+		prefix = (prefix + w*digit) % 11
+	}
+	if prefix == target {
+		count++
+	}
+	return count
+}
+
+func pow10(n int) uint64 {
+	r := uint64(1)
+	for ; n > 0; n-- {
+		r *= 10
+	}
+	return r
+}
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,171 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleRange() {
+	it := Range(20242643700, 20242643800)
+	for it.Next() {
+		fmt.Println(it.CUIT())
+	}
+	// Output:
+	// 20242643705
+	// 20242643713
+	// 20242643721
+	// 20242643748
+	// 20242643756
+	// 20242643764
+	// 20242643772
+	// 20242643780
+	// 20242643799
+}
+
+func ExampleCount() {
+	fmt.Println(Count(20242643700, 20242643800))
+	fmt.Println(Count(Min, Max))
+	// Output:
+	// 9
+	// 636363636
+}
+
+func ExampleSpan_Split() {
+	for _, s := range KindSpan(30).Split(3) {
+		fmt.Println(Format(s.From), Format(s.To), s.Count())
+	}
+	// Output:
+	// 30-00000000-0 30-33333334-7 30303030
+	// 30-33333334-8 30-66666666-9 30303030
+	// 30-66666667-0 30-99999999-9 30303031
+}
+
+// naiveCount counts valid cuits in [from, to] by brute force.
+func naiveCount(from, to uint64) uint64 {
+	var n uint64
+	for c := from; c <= to; c++ {
+		if IsValid(c) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRange(t *testing.T) {
+	a := assert.New(t)
+	it := Range(20999999000, 23000001000)
+	var got []uint64
+	for it.Next() {
+		got = append(got, it.CUIT())
+	}
+	a.False(it.Next(), "exhausted iterator")
+	a.Equal(uint64(0), it.CUIT())
+	a.Equal(Count(20999999000, 23000001000), uint64(len(got)))
+	for i, c := range got[1:] {
+		a.True(IsValid(c))
+		if c/1e9 == got[i]/1e9 {
+			a.Equal(Succ(got[i]), c)
+		} else {
+			a.Equal(uint64(23), c/1e9, "kinds 21 and 22 skipped")
+		}
+	}
+	it = Range(0, 20000000100)
+	a.True(it.Next())
+	a.Equal(uint64(Min), it.CUIT())
+	it = Range(34999999900, 1e12)
+	var last uint64
+	for it.Next() {
+		last = it.CUIT()
+	}
+	a.Equal(uint64(Max), last)
+	a.False(Range(Max+1, 1e12).Next())
+	a.False(Range(20242643773, 20242643779).Next())
+	a.False(Range(30, 20).Next())
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name string
+		from uint64
+		to   uint64
+		want uint64
+	}{
+		{"empty", 20, 10, 0},
+		{"single valid", 20242643772, 20242643772, 1},
+		{"single invalid", 20242643773, 20242643773, 0},
+		{"partial ids", 20242643773, 20242643781, naiveCount(20242643773, 20242643781)},
+		{"kind boundary", 20999999000, 23000001000, naiveCount(20999999000, 20999999999) + naiveCount(23000000000, 23000001000)},
+		{"invalid kinds", 21000000000, 22999999999, 0},
+		{"below min", 0, 20000001000, naiveCount(20000000000, 20000001000)},
+		{"above max", 34999999000, 1e12, naiveCount(34999999000, 34999999999)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, Count(test.from, test.to))
+		})
+	}
+	t.Run("random", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(1))
+		for i := 0; i < 200; i++ {
+			from := Min + rnd.Uint64()%(Max-Min)
+			to := from + rnd.Uint64()%10000
+			assert.Equal(t, naiveCount(from, to), Count(from, to), "[%d, %d]", from, to)
+		}
+	})
+}
+
+func TestSplit(t *testing.T) {
+	a := assert.New(t)
+	a.Nil(Span{From: Min, To: Max}.Split(0))
+	a.Equal([]Span{{From: 21000000000, To: 21999999999}}, Span{From: 21000000000, To: 21999999999}.Split(4))
+	for _, n := range []int{1, 2, 7, 16} {
+		s := Span{From: 20242643700, To: 20242648700}
+		spans := s.Split(n)
+		a.Len(spans, n)
+		a.Equal(s.From, spans[0].From)
+		a.Equal(s.To, spans[n-1].To)
+		var total uint64
+		for i, sp := range spans {
+			if i > 0 {
+				a.Equal(spans[i-1].To+1, sp.From, "contiguous")
+			}
+			c := sp.Count()
+			a.InDelta(float64(s.Count())/float64(n), float64(c), 1, "balanced")
+			total += c
+		}
+		a.Equal(s.Count(), total)
+	}
+	spans := Span{From: 20242643770, To: 20242643775}.Split(3)
+	a.Len(spans, 3)
+	var total uint64
+	for _, sp := range spans {
+		total += sp.Count()
+	}
+	a.Equal(uint64(1), total)
+}
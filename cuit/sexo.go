@@ -0,0 +1,55 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import "strconv"
+
+const (
+	// SexoMasculino es el sexo masculino ("M" en el DNI)
+	SexoMasculino Sexo = iota
+
+	// SexoFemenino es el sexo femenino ("F" en el DNI)
+	SexoFemenino
+
+	// SexoNoBinario es el sexo no binario ("X" en el DNI)
+	SexoNoBinario
+)
+
+// sexo es un tipo privado para impedir creación de Sexo fuera de este paquete
+type sexo uint8
+
+// Sexo es un enumerado de los sexos que pueden declararse en un DNI
+type Sexo sexo
+
+func (s Sexo) String() string {
+	switch s {
+	case SexoMasculino:
+		return "Masculino"
+	case SexoFemenino:
+		return "Femenino"
+	case SexoNoBinario:
+		return "No Binario"
+	default:
+		return "Sexo(" + strconv.Itoa(int(s)) + ")"
+	}
+}
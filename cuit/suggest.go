@@ -0,0 +1,78 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import "sort"
+
+// Suggest returns the valid CUIT numbers that can be obtained from input by
+// replacing a single digit or by swapping two adjacent digits, in ascending
+// order.
+//
+// It is intended to propose corrections for mistyped numbers so it returns
+// nil when input is already valid or when it has more than eleven digits.
+func Suggest(input uint64) []uint64 {
+	if !validSize(input) || IsValid(input) {
+		return nil
+	}
+	var digits [11]uint64
+	for i, rem := len(digits)-1, input; i >= 0; i-- {
+		digits[i] = rem % 10
+		rem /= 10
+	}
+	found := map[uint64]bool{}
+	try := func() {
+		var c uint64
+		for _, d := range digits {
+			c = c*10 + d
+		}
+		if IsValid(c) {
+			found[c] = true
+		}
+	}
+	for i := range digits {
+		orig := digits[i]
+		for d := uint64(0); d < 10; d++ {
+			if d != orig {
+				digits[i] = d
+				try()
+			}
+		}
+		digits[i] = orig
+	}
+	for i := 0; i < len(digits)-1; i++ {
+		if digits[i] != digits[i+1] {
+			digits[i], digits[i+1] = digits[i+1], digits[i]
+			try()
+			digits[i], digits[i+1] = digits[i+1], digits[i]
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	res := make([]uint64, 0, len(found))
+	for c := range found {
+		res = append(res, c)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
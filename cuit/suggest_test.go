@@ -0,0 +1,76 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSuggest() {
+	// wrong verifier digit
+	for _, c := range Suggest(20242643773) {
+		fmt.Println(Format(c))
+	}
+	// Output:
+	// 20-22464377-3
+	// 20-24244377-3
+	// 20-24263477-3
+	// 20-24264077-3
+	// 20-24264337-3
+	// 20-24264371-3
+	// 20-24264377-2
+	// 20-24266377-3
+	// 20-24564377-3
+	// 20-29264377-3
+	// 20-94264377-3
+}
+
+func TestSuggest(t *testing.T) {
+	a := assert.New(t)
+	a.Nil(Suggest(20242643772), "valid input")
+	a.Nil(Suggest(1e11), "too big")
+	a.Contains(Suggest(20246243772), uint64(20242643772), "transposition")
+	a.Contains(Suggest(20242643572), uint64(20242643772), "substitution")
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		valid := Random(rnd)
+		pos := uint64(1)
+		for j := rnd.Intn(11); j > 0; j-- {
+			pos *= 10
+		}
+		digit := valid / pos % 10
+		typo := valid - digit*pos + (digit+1)%10*pos
+		if IsValid(typo) {
+			continue
+		}
+		got := Suggest(typo)
+		a.Contains(got, valid, "typo %d", typo)
+		for _, c := range got {
+			a.True(IsValid(c))
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatError is returned when a string can not be parsed as a CUIT number.
+type FormatError struct {
+	Input string
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("formato incorrecto de cuit/cuil: %q", e.Input)
+}
+
+// RangeError is returned when a CUIT number has more than eleven digits.
+type RangeError struct {
+	CUIT uint64
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("el cuit/cuil %d tiene más de 11 dígitos", e.CUIT)
+}
+
+// KindError is returned when a CUIT number has an unknown kind prefix.
+type KindError struct {
+	CUIT uint64
+	Kind uint64
+}
+
+func (e *KindError) Error() string {
+	return fmt.Sprintf("el prefijo %02d del cuit/cuil %s no es válido (debe ser %s)", e.Kind, Format(e.CUIT), kindsList())
+}
+
+// VerifierError is returned when a CUIT number has an incorrect verifier
+// digit. Want holds the correct one.
+type VerifierError struct {
+	CUIT uint64
+	Got  uint64
+	Want uint64
+}
+
+func (e *VerifierError) Error() string {
+	return fmt.Sprintf("el dígito verificador del cuit/cuil %s es incorrecto (debería ser %d)", Format(e.CUIT), e.Want)
+}
+
+// NonexistentError is returned when a CUIT number can not exist because
+// there is no possible verifier digit for its kind and identifier (that is,
+// Verifier returns 10).
+type NonexistentError struct {
+	CUIT uint64
+}
+
+func (e *NonexistentError) Error() string {
+	kind, id, _ := Parts(e.CUIT)
+	return fmt.Sprintf("no existe cuit/cuil con prefijo %02d y número %08d", kind, id)
+}
+
+// Validate is like IsValid but returns an error explaining why the cuit is
+// not valid or nil if it is.
+//
+// The returned error is one of *RangeError, *KindError, *NonexistentError or
+// *VerifierError and can be inspected using errors.As.
+func Validate(cuit uint64) error {
+	if !validSize(cuit) {
+		return &RangeError{CUIT: cuit}
+	}
+	if !validKind(cuit) {
+		kind, _, _ := Parts(cuit)
+		return &KindError{CUIT: cuit, Kind: kind}
+	}
+	if !validVerifier(cuit) {
+		want := Verifier(cuit)
+		if want == 10 {
+			return &NonexistentError{CUIT: cuit}
+		}
+		return &VerifierError{CUIT: cuit, Got: cuit % 10, Want: want}
+	}
+	return nil
+}
+
+// ValidateString parses cuit using Parse and validates the result using
+// Validate.
+//
+// Besides the errors returned by Validate it can return a *FormatError.
+func ValidateString(cuit string) error {
+	c, err := Parse(cuit)
+	if err != nil {
+		return err
+	}
+	return Validate(c)
+}
+
+func kindsList() string {
+	ks := make([]string, len(allkinds))
+	for i, k := range allkinds {
+		ks[i] = strconv.FormatUint(k, 10)
+	}
+	return strings.Join(ks[:len(ks)-1], ", ") + " o " + ks[len(ks)-1]
+}
@@ -0,0 +1,97 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleValidate() {
+	err := Validate(20242643773)
+	var verr *VerifierError
+	if errors.As(err, &verr) {
+		fmt.Println(verr.Want)
+	}
+	fmt.Println(err)
+	// Output:
+	// 2
+	// el dígito verificador del cuit/cuil 20-24264377-3 es incorrecto (debería ser 2)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cuit uint64
+		want error
+	}{
+		{"valid", 20242643772, nil},
+		{"valid legal", 33693450239, nil},
+		{"bad verifier", 20242643773, &VerifierError{CUIT: 20242643773, Got: 3, Want: 2}},
+		{"bad kind", 31711413568, &KindError{CUIT: 31711413568, Kind: 31}},
+		{"nonexistent", 20000000015, &NonexistentError{CUIT: 20000000015}},
+		{"too big", 10030711413568, &RangeError{CUIT: 10030711413568}},
+		{"too small", 100, &KindError{CUIT: 100, Kind: 0}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got := Validate(test.cuit)
+			if test.want == nil {
+				assert.NoError(t, got)
+			} else {
+				assert.Equal(t, test.want, got)
+			}
+			assert.Equal(t, IsValid(test.cuit), got == nil)
+		})
+	}
+}
+
+func TestValidateString(t *testing.T) {
+	a := assert.New(t)
+	a.NoError(ValidateString("20-24264377-2"))
+	var ferr *FormatError
+	a.True(errors.As(ValidateString("20-2426437-2"), &ferr))
+	a.Equal("20-2426437-2", ferr.Input)
+	var verr *VerifierError
+	a.True(errors.As(ValidateString("20242643773"), &verr))
+}
+
+func TestErrorMessages(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&FormatError{Input: "x"}, `formato incorrecto de cuit/cuil: "x"`},
+		{&RangeError{CUIT: 123456789012}, "el cuit/cuil 123456789012 tiene más de 11 dígitos"},
+		{&KindError{CUIT: 31711413568, Kind: 31}, "el prefijo 31 del cuit/cuil 31-71141356-8 no es válido (debe ser 20, 23, 24, 27, 30, 33 o 34)"},
+		{&VerifierError{CUIT: 20242643773, Got: 3, Want: 2}, "el dígito verificador del cuit/cuil 20-24264377-3 es incorrecto (debería ser 2)"},
+		{&NonexistentError{CUIT: 20000000015}, "no existe cuit/cuil con prefijo 20 y número 00000001"},
+	}
+	for _, test := range tests {
+		assert.EqualError(t, test.err, test.want)
+	}
+}
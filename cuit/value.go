@@ -0,0 +1,206 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// CUIT is a CUIT/CUIL number.
+//
+// Its zero value represents the absence of a number and it is encoded as null
+// in JSON and as NULL in SQL. Every decoding method rejects numbers that are
+// not valid according to IsValid, so a CUIT obtained by decoding is always
+// either zero or valid.
+type CUIT uint64
+
+// New returns cuit as a CUIT if it is valid or the error returned by
+// Validate if it is not.
+func New(cuit uint64) (CUIT, error) {
+	if err := Validate(cuit); err != nil {
+		return 0, err
+	}
+	return CUIT(cuit), nil
+}
+
+// ParseCUIT is like Parse but returns a CUIT and also fails when the parsed
+// number is not valid.
+func ParseCUIT(s string) (CUIT, error) {
+	cuit, err := Parse(s)
+	if err != nil {
+		return 0, err
+	}
+	return New(cuit)
+}
+
+// Uint64 returns c as a bare uint64.
+func (c CUIT) Uint64() uint64 {
+	return uint64(c)
+}
+
+// IsZero reports whether c is the zero value.
+func (c CUIT) IsZero() bool {
+	return c == 0
+}
+
+// IsValid is like the IsValid function.
+func (c CUIT) IsValid() bool {
+	return IsValid(uint64(c))
+}
+
+// Parts is like the Parts function.
+func (c CUIT) Parts() (kind, id, ver uint64) {
+	return Parts(uint64(c))
+}
+
+// Format is like the Format function.
+func (c CUIT) Format() string {
+	return Format(uint64(c))
+}
+
+// Pred is like the Pred function.
+func (c CUIT) Pred() CUIT {
+	return CUIT(Pred(uint64(c)))
+}
+
+// Succ is like the Succ function.
+func (c CUIT) Succ() CUIT {
+	return CUIT(Succ(uint64(c)))
+}
+
+// TipoPersona is like the TipoPersonaCUIT function.
+func (c CUIT) TipoPersona() TipoPersona {
+	return TipoPersonaCUIT(uint64(c))
+}
+
+// String implements fmt.Stringer using the standard format.
+func (c CUIT) String() string {
+	return c.Format()
+}
+
+// MarshalText implements encoding.TextMarshaler using the standard format.
+//
+// The zero value is encoded as an empty text.
+func (c CUIT) MarshalText() ([]byte, error) {
+	if c.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(c.Format()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler accepting the formats
+// supported by Parse.
+//
+// An empty text is decoded as the zero value.
+func (c *CUIT) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = 0
+		return nil
+	}
+	v, err := ParseCUIT(string(text))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler encoding c as a JSON number.
+//
+// The zero value is encoded as null.
+func (c CUIT) MarshalJSON() ([]byte, error) {
+	if c.IsZero() {
+		return []byte("null"), nil
+	}
+	return strconv.AppendUint(nil, uint64(c), 10), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler accepting either a JSON number or
+// a JSON string in any of the formats supported by Parse.
+//
+// As usual, null leaves c unchanged.
+func (c *CUIT) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.Wrap(err, "decodificando cuit/cuil")
+		}
+		return c.UnmarshalText([]byte(s))
+	}
+	n, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return &FormatError{Input: string(data)}
+	}
+	v, err := New(n)
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// Scan implements sql.Scanner accepting integer, string and []byte values.
+//
+// NULL is scanned as the zero value.
+func (c *CUIT) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = 0
+		return nil
+	case int64:
+		if v < 0 {
+			return errors.Errorf("cuit/cuil negativo: %d", v)
+		}
+		n, err := New(uint64(v))
+		if err != nil {
+			return err
+		}
+		*c = n
+		return nil
+	case string:
+		return c.UnmarshalText([]byte(v))
+	case []byte:
+		return c.UnmarshalText(v)
+	default:
+		return errors.Errorf("no es posible obtener un cuit/cuil desde %T", src)
+	}
+}
+
+// Value implements driver.Valuer returning c as an int64.
+//
+// The zero value is returned as NULL.
+func (c CUIT) Value() (driver.Value, error) {
+	if c.IsZero() {
+		return nil, nil
+	}
+	return int64(c), nil
+}
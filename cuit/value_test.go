@@ -0,0 +1,195 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cuit
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleCUIT() {
+	c := CUIT(20242643772)
+	fmt.Println(c)
+	fmt.Println(c.IsValid())
+	fmt.Println(c.Succ())
+	fmt.Println(c.TipoPersona())
+	// Output:
+	// 20-24264377-2
+	// true
+	// 20-24264378-0
+	// Persona Física
+}
+
+func ExampleCUIT_json() {
+	var v struct {
+		A CUIT
+		B CUIT
+		C CUIT
+	}
+	err := json.Unmarshal([]byte(`{"A":20242643772,"B":"33-69345023-9"}`), &v)
+	if err != nil {
+		// handle error
+	}
+	bs, _ := json.Marshal(v)
+	fmt.Println(string(bs))
+	// Output: {"A":20242643772,"B":33693450239,"C":null}
+}
+
+func TestNew(t *testing.T) {
+	a := assert.New(t)
+	c, err := New(20242643772)
+	a.NoError(err)
+	a.Equal(CUIT(20242643772), c)
+	_, err = New(20242643773)
+	a.Error(err)
+}
+
+func TestParseCUIT(t *testing.T) {
+	tests := []struct {
+		name    string
+		cuit    string
+		want    CUIT
+		wantErr bool
+	}{
+		{"basic", "20-24264377-2", 20242643772, false},
+		{"no dashes", "20242643772", 20242643772, false},
+		{"bad verifier", "20-24264377-3", 0, true},
+		{"bad format", "20-2426437-2", 0, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseCUIT(test.cuit)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestCUITText(t *testing.T) {
+	a := assert.New(t)
+	bs, err := CUIT(20242643772).MarshalText()
+	a.NoError(err)
+	a.Equal("20-24264377-2", string(bs))
+	bs, err = CUIT(0).MarshalText()
+	a.NoError(err)
+	a.Empty(bs)
+	var c CUIT
+	a.NoError(c.UnmarshalText([]byte("20242643772")))
+	a.Equal(CUIT(20242643772), c)
+	a.NoError(c.UnmarshalText(nil))
+	a.True(c.IsZero())
+	a.Error(c.UnmarshalText([]byte("20-24264377-3")))
+	a.Error(c.UnmarshalText([]byte("nada")))
+}
+
+func TestCUITJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    CUIT
+		wantErr bool
+	}{
+		{"number", `20242643772`, 20242643772, false},
+		{"string", `"20-24264377-2"`, 20242643772, false},
+		{"string no dashes", `"20242643772"`, 20242643772, false},
+		{"null", `null`, 0, false},
+		{"invalid number", `20242643773`, 0, true},
+		{"invalid string", `"20-24264377-3"`, 0, true},
+		{"negative", `-20242643772`, 0, true},
+		{"float", `2.0242643772e10`, 0, true},
+		{"bool", `true`, 0, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var got CUIT
+			err := json.Unmarshal([]byte(test.json), &got)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+	t.Run("roundtrip", func(t *testing.T) {
+		for _, c := range []CUIT{0, 20242643772, 33693450239} {
+			bs, err := json.Marshal(c)
+			assert.NoError(t, err)
+			var got CUIT
+			assert.NoError(t, json.Unmarshal(bs, &got))
+			assert.Equal(t, c, got)
+		}
+	})
+}
+
+func TestCUITSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    CUIT
+		wantErr bool
+	}{
+		{"int64", int64(20242643772), 20242643772, false},
+		{"string", "20-24264377-2", 20242643772, false},
+		{"bytes", []byte("20242643772"), 20242643772, false},
+		{"nil", nil, 0, false},
+		{"invalid int64", int64(20242643773), 0, true},
+		{"negative int64", int64(-1), 0, true},
+		{"invalid string", "20-24264377-3", 0, true},
+		{"unsupported", 1.5, 0, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var got CUIT
+			err := got.Scan(test.src)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+	t.Run("negative message", func(t *testing.T) {
+		var c CUIT
+		assert.EqualError(t, c.Scan(int64(-5)), "cuit/cuil negativo: -5")
+	})
+	t.Run("value", func(t *testing.T) {
+		v, err := CUIT(20242643772).Value()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20242643772), v)
+		v, err = CUIT(0).Value()
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+	})
+}
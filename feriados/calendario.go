@@ -0,0 +1,304 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package feriados
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lalloni/afip/periodo"
+)
+
+// Calendario determina los días hábiles a partir de los feriados
+// nacionales y de los feriados agregados o quitados explícitamente.
+//
+// Son hábiles los días de lunes a viernes que no son feriados.
+//
+// Puede usarse concurrentemente desde varias goroutines.
+type Calendario struct {
+	mu        sync.RWMutex
+	agregados map[dia]Feriado
+	quitados  map[dia]bool
+	anuales   map[int]map[dia]Feriado
+}
+
+// New construye un calendario con los feriados nacionales.
+func New() *Calendario {
+	return &Calendario{
+		agregados: map[dia]Feriado{},
+		quitados:  map[dia]bool{},
+		anuales:   map[int]map[dia]Feriado{},
+	}
+}
+
+// predeterminado es el calendario usado por las funciones del paquete.
+var predeterminado = New()
+
+// Add agrega el feriado f al calendario reemplazando al que hubiera en la
+// misma fecha.
+//
+// Retorna error si la fecha de f no es un período diario de un único día.
+func (c *Calendario) Add(f Feriado) error {
+	d, err := diaPeriodo(f.Fecha)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.quitados, d)
+	c.agregados[d] = f
+	return nil
+}
+
+// Remove quita del calendario el feriado del día de t, si lo hay, de modo
+// que pase a ser hábil si no cae sábado o domingo.
+func (c *Calendario) Remove(t time.Time) {
+	d := diaDe(t)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.agregados, d)
+	c.quitados[d] = true
+}
+
+// feriado retorna el feriado del día d.
+func (c *Calendario) feriado(d dia) (Feriado, bool) {
+	c.mu.RLock()
+	if c.quitados[d] {
+		c.mu.RUnlock()
+		return Feriado{}, false
+	}
+	if f, ok := c.agregados[d]; ok {
+		c.mu.RUnlock()
+		return f, true
+	}
+	fs, ok := c.anuales[d.y]
+	c.mu.RUnlock()
+	if !ok {
+		fs = nacionales(d.y)
+		c.mu.Lock()
+		c.anuales[d.y] = fs
+		c.mu.Unlock()
+	}
+	f, ok := fs[d]
+	return f, ok
+}
+
+// Feriado retorna el feriado del día de t, si lo hay.
+func (c *Calendario) Feriado(t time.Time) (Feriado, bool) {
+	return c.feriado(diaDe(t))
+}
+
+// Feriados retorna los feriados del año y ordenados por fecha.
+func (c *Calendario) Feriados(y int) []Feriado {
+	var fs []Feriado
+	for d := (dia{y, time.January, 1}); d.y == y; d = d.next(1) {
+		if f, ok := c.feriado(d); ok {
+			fs = append(fs, f)
+		}
+	}
+	sort.Slice(fs, func(i, j int) bool { return fs[i].Fecha.Before(fs[j].Fecha) })
+	return fs
+}
+
+func (d dia) next(n int) dia {
+	return diaDe(d.time().AddDate(0, 0, n))
+}
+
+func (c *Calendario) habil(d dia) bool {
+	switch d.time().Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	_, feriado := c.feriado(d)
+	return !feriado
+}
+
+// IsBusinessDay informa si el día de t es hábil.
+func (c *Calendario) IsBusinessDay(t time.Time) bool {
+	return c.habil(diaDe(t))
+}
+
+// NextBusinessDay retorna el primer día hábil posterior al día de t,
+// conservando la hora y la zona horaria de t.
+func (c *Calendario) NextBusinessDay(t time.Time) time.Time {
+	return c.AddBusinessDays(t, 1)
+}
+
+// AddBusinessDays retorna el n-ésimo día hábil posterior al día de t, o
+// anterior si n es negativo, conservando la hora y la zona horaria de t.
+// Si n es 0 retorna t.
+func (c *Calendario) AddBusinessDays(t time.Time, n int) time.Time {
+	d := diaDe(t)
+	return t.AddDate(0, 0, c.add(d, n).sub(d))
+}
+
+func (c *Calendario) add(d dia, n int) dia {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		d = d.next(step)
+		for !c.habil(d) {
+			d = d.next(step)
+		}
+	}
+	return d
+}
+
+// sub retorna la cantidad de días entre o y d.
+func (d dia) sub(o dia) int {
+	return int(d.time().Sub(o.time()).Hours() / 24)
+}
+
+// BusinessDaysBetween retorna la cantidad de días hábiles posteriores al
+// día de from hasta el día de to inclusive, o su opuesto si to es anterior
+// a from.
+//
+// Por lo tanto, si to es un día hábil, AddBusinessDays(from,
+// BusinessDaysBetween(from, to)) es el día de to.
+func (c *Calendario) BusinessDaysBetween(from, to time.Time) int {
+	return c.between(diaDe(from), diaDe(to))
+}
+
+func (c *Calendario) between(from, to dia) int {
+	if to.sub(from) < 0 {
+		return -c.between(to, from)
+	}
+	n := 0
+	for d := from.next(1); d.sub(to) <= 0; d = d.next(1) {
+		if c.habil(d) {
+			n++
+		}
+	}
+	return n
+}
+
+// diaPeriodo retorna el día del período diario p.
+func diaPeriodo(p periodo.Periodo) (dia, error) {
+	if p.Tipo() != periodo.Diario || p.Month() == 0 || p.Day() == 0 {
+		return dia{}, errors.Errorf("el período %s no es un período diario de un único día", p)
+	}
+	return dia{int(p.Year()), time.Month(p.Month()), int(p.Day())}, nil
+}
+
+// IsBusinessDayPeriodo es como IsBusinessDay pero para el día del período
+// diario p.
+//
+// Retorna error si p no es un período diario de un único día.
+func (c *Calendario) IsBusinessDayPeriodo(p periodo.Periodo) (bool, error) {
+	d, err := diaPeriodo(p)
+	if err != nil {
+		return false, err
+	}
+	return c.habil(d), nil
+}
+
+// NextBusinessDayPeriodo es como NextBusinessDay pero para el día del
+// período diario p.
+//
+// Retorna error si p no es un período diario de un único día.
+func (c *Calendario) NextBusinessDayPeriodo(p periodo.Periodo) (periodo.Periodo, error) {
+	return c.AddBusinessDaysPeriodo(p, 1)
+}
+
+// AddBusinessDaysPeriodo es como AddBusinessDays pero para el día del
+// período diario p.
+//
+// Retorna error si p no es un período diario de un único día o si el
+// resultado está fuera del rango de años válidos.
+func (c *Calendario) AddBusinessDaysPeriodo(p periodo.Periodo, n int) (periodo.Periodo, error) {
+	d, err := diaPeriodo(p)
+	if err != nil {
+		return periodo.Periodo{}, err
+	}
+	d = c.add(d, n)
+	return periodo.NewDiario(uint(d.y), uint(d.m), uint(d.d))
+}
+
+// BusinessDaysBetweenPeriodo es como BusinessDaysBetween pero para los
+// días de los períodos diarios from y to.
+//
+// Retorna error si from o to no son períodos diarios de un único día.
+func (c *Calendario) BusinessDaysBetweenPeriodo(from, to periodo.Periodo) (int, error) {
+	f, err := diaPeriodo(from)
+	if err != nil {
+		return 0, err
+	}
+	t, err := diaPeriodo(to)
+	if err != nil {
+		return 0, err
+	}
+	return c.between(f, t), nil
+}
+
+// IsBusinessDay es como Calendario.IsBusinessDay usando sólo los feriados
+// nacionales.
+func IsBusinessDay(t time.Time) bool {
+	return predeterminado.IsBusinessDay(t)
+}
+
+// NextBusinessDay es como Calendario.NextBusinessDay usando sólo los
+// feriados nacionales.
+func NextBusinessDay(t time.Time) time.Time {
+	return predeterminado.NextBusinessDay(t)
+}
+
+// AddBusinessDays es como Calendario.AddBusinessDays usando sólo los
+// feriados nacionales.
+func AddBusinessDays(t time.Time, n int) time.Time {
+	return predeterminado.AddBusinessDays(t, n)
+}
+
+// BusinessDaysBetween es como Calendario.BusinessDaysBetween usando sólo
+// los feriados nacionales.
+func BusinessDaysBetween(from, to time.Time) int {
+	return predeterminado.BusinessDaysBetween(from, to)
+}
+
+// IsBusinessDayPeriodo es como Calendario.IsBusinessDayPeriodo usando sólo
+// los feriados nacionales.
+func IsBusinessDayPeriodo(p periodo.Periodo) (bool, error) {
+	return predeterminado.IsBusinessDayPeriodo(p)
+}
+
+// NextBusinessDayPeriodo es como Calendario.NextBusinessDayPeriodo usando
+// sólo los feriados nacionales.
+func NextBusinessDayPeriodo(p periodo.Periodo) (periodo.Periodo, error) {
+	return predeterminado.NextBusinessDayPeriodo(p)
+}
+
+// AddBusinessDaysPeriodo es como Calendario.AddBusinessDaysPeriodo usando
+// sólo los feriados nacionales.
+func AddBusinessDaysPeriodo(p periodo.Periodo, n int) (periodo.Periodo, error) {
+	return predeterminado.AddBusinessDaysPeriodo(p, n)
+}
+
+// BusinessDaysBetweenPeriodo es como Calendario.BusinessDaysBetweenPeriodo
+// usando sólo los feriados nacionales.
+func BusinessDaysBetweenPeriodo(from, to periodo.Periodo) (int, error) {
+	return predeterminado.BusinessDaysBetweenPeriodo(from, to)
+}
@@ -0,0 +1,123 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package feriados
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/lalloni/afip/periodo"
+)
+
+// habil es el tipo usado en los archivos para quitar feriados.
+const habil = "habil"
+
+// Load agrega o quita feriados del calendario según los registros leídos
+// desde r en formato CSV.
+//
+// Cada registro debe tener la fecha con la forma YYYY-MM-DD, el tipo de
+// feriado (inamovible, trasladable o puente) o "habil" para quitar el
+// feriado de esa fecha y, opcionalmente, el nombre del feriado. Se ignoran
+// las líneas vacías, las que comienzan con # y un primer registro de
+// encabezado que comience con "fecha". Los registros no pueden ocupar más
+// de una línea. Por ejemplo:
+//
+//	fecha,tipo,nombre
+//	2022-11-21,puente,Feriado con fines turísticos
+//	2022-11-20,habil
+//
+// En caso de error los cambios leídos antes del registro erróneo quedan
+// aplicados.
+func (c *Calendario) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	first := true
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		cr := csv.NewReader(strings.NewReader(text))
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		rec, err := cr.Read()
+		if err == nil && first && strings.EqualFold(rec[0], "fecha") {
+			first = false
+			continue
+		}
+		first = false
+		if err == nil {
+			err = c.load(rec)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "línea %d", line)
+		}
+	}
+	return errors.Wrap(scanner.Err(), "leyendo feriados")
+}
+
+func (c *Calendario) load(rec []string) error {
+	if len(rec) < 2 || len(rec) > 3 {
+		return errors.Errorf("el registro tiene %d columnas y se esperaban 2 o 3", len(rec))
+	}
+	p, err := periodo.ParseLayoutTipo(periodo.ISO, periodo.Diario, strings.TrimSpace(rec[0]))
+	if err != nil {
+		return err
+	}
+	d, err := diaPeriodo(p)
+	if err != nil {
+		return err
+	}
+	f := Feriado{Fecha: p}
+	if len(rec) == 3 {
+		f.Nombre = strings.TrimSpace(rec[2])
+	}
+	switch tipo := strings.ToLower(strings.TrimSpace(rec[1])); tipo {
+	case habil:
+		c.Remove(d.time())
+		return nil
+	case "inamovible":
+		f.Tipo = Inamovible
+	case "trasladable":
+		f.Tipo = Trasladable
+	case "puente":
+		f.Tipo = Puente
+	default:
+		return errors.Errorf("tipo de feriado desconocido: %q", rec[1])
+	}
+	return c.Add(f)
+}
+
+// LoadFile es como Load pero lee los feriados desde el archivo path.
+func (c *Calendario) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "abriendo archivo de feriados")
+	}
+	defer f.Close()
+	return errors.Wrap(c.Load(f), path)
+}
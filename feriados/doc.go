@@ -0,0 +1,37 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package feriados permite determinar los días hábiles de Argentina y
+// operar con ellos.
+//
+// Incluye los feriados nacionales inamovibles y trasladables establecidos
+// por la Ley 27.399 a partir de 2018, su primer año completo de vigencia, y
+// los feriados con fines turísticos (días puente) decretados desde entonces
+// hasta la fecha de publicación de este paquete.
+// Como los días puente se decretan año a año y el Poder Ejecutivo puede
+// modificar los traslados, los feriados pueden completarse o corregirse
+// cargándolos desde archivos (ver Calendario.Load).
+//
+// Todas las funciones trabajan con el día calendario de los time.Time que
+// reciben en su propia zona horaria, o con períodos diarios del paquete
+// periodo.
+package feriados
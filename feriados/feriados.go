@@ -0,0 +1,218 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package feriados
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lalloni/afip/periodo"
+)
+
+// Tipo es el tipo de un feriado.
+type Tipo uint8
+
+const (
+	// Inamovible es el tipo de los feriados que se celebran siempre en la
+	// misma fecha o, como Carnaval y Viernes Santo, en una fecha que sólo
+	// depende del año.
+	Inamovible Tipo = iota + 1
+	// Trasladable es el tipo de los feriados que se trasladan al lunes
+	// anterior o siguiente según el día de la semana en que caen.
+	Trasladable
+	// Puente es el tipo de los feriados con fines turísticos que el Poder
+	// Ejecutivo decreta cada año.
+	Puente
+)
+
+func (t Tipo) String() string {
+	switch t {
+	case Inamovible:
+		return "Inamovible"
+	case Trasladable:
+		return "Trasladable"
+	case Puente:
+		return "Puente"
+	default:
+		return "Tipo(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// Feriado es un día no laborable.
+type Feriado struct {
+	// Fecha es el período diario en que se observa el feriado, que en los
+	// trasladables puede no coincidir con la fecha que conmemoran.
+	Fecha periodo.Periodo
+	// Tipo es el tipo del feriado.
+	Tipo Tipo
+	// Nombre es la descripción del feriado.
+	Nombre string
+}
+
+// dia es un día del calendario.
+type dia struct {
+	y int
+	m time.Month
+	d int
+}
+
+func diaDe(t time.Time) dia {
+	y, m, d := t.Date()
+	return dia{y, m, d}
+}
+
+func (d dia) time() time.Time {
+	return time.Date(d.y, d.m, d.d, 0, 0, 0, 0, time.UTC)
+}
+
+func (d dia) periodo() periodo.Periodo {
+	p, err := periodo.NewDiario(uint(d.y), uint(d.m), uint(d.d))
+	if err != nil {
+		// Nunca debería ocurrir porque los días se obtienen de períodos
+		// diarios válidos o de años con reglas definidas.
+		panic(err)
+	}
+	return p
+}
+
+// desde es el primer año completo en que se aplican las reglas de la Ley
+// 27.399, sancionada en octubre de 2017.
+const desde = 2018
+
+// inamovibles son los feriados inamovibles de fecha fija.
+var inamovibles = []struct {
+	m    time.Month
+	d    int
+	name string
+}{
+	{time.January, 1, "Año Nuevo"},
+	{time.March, 24, "Día Nacional de la Memoria por la Verdad y la Justicia"},
+	{time.April, 2, "Día del Veterano y de los Caídos en la Guerra de Malvinas"},
+	{time.May, 1, "Día del Trabajador"},
+	{time.May, 25, "Día de la Revolución de Mayo"},
+	{time.June, 20, "Paso a la Inmortalidad del General Manuel Belgrano"},
+	{time.July, 9, "Día de la Independencia"},
+	{time.December, 8, "Inmaculada Concepción de María"},
+	{time.December, 25, "Navidad"},
+}
+
+// trasladables son los feriados trasladables según la fecha que conmemoran.
+var trasladables = []struct {
+	m    time.Month
+	d    int
+	name string
+}{
+	{time.June, 17, "Paso a la Inmortalidad del General Martín Miguel de Güemes"},
+	{time.August, 17, "Paso a la Inmortalidad del General José de San Martín"},
+	{time.October, 12, "Día del Respeto a la Diversidad Cultural"},
+	{time.November, 20, "Día de la Soberanía Nacional"},
+}
+
+// puentes son los feriados con fines turísticos decretados.
+var puentes = []dia{
+	// Decreto 923/2017
+	{2018, time.April, 30},
+	{2018, time.December, 24},
+	{2018, time.December, 31},
+	// Decreto 1027/2018
+	{2019, time.July, 8},
+	{2019, time.August, 19},
+	{2019, time.October, 14},
+	// Decreto 717/2019
+	{2020, time.March, 23},
+	{2020, time.July, 10},
+	{2020, time.December, 7},
+	// Decreto 947/2020
+	{2021, time.May, 24},
+	{2021, time.October, 8},
+	{2021, time.November, 22},
+}
+
+const nombrePuente = "Feriado con fines turísticos"
+
+// nacionales retorna los feriados nacionales del año y o nil si es anterior
+// a la vigencia de la Ley 27.399 o no puede representarse con un período.
+func nacionales(y int) map[dia]Feriado {
+	if y < desde || y > int(periodo.MaxYear) {
+		return nil
+	}
+	fs := map[dia]Feriado{}
+	add := func(d dia, t Tipo, name string) {
+		// Los feriados que coinciden, como Viernes Santo y el 2 de abril de
+		// 2021, se combinan conservando el tipo del primero.
+		if f, ok := fs[d]; ok {
+			f.Nombre += "; " + name
+			fs[d] = f
+			return
+		}
+		fs[d] = Feriado{Fecha: d.periodo(), Tipo: t, Nombre: name}
+	}
+	for _, f := range inamovibles {
+		add(dia{y, f.m, f.d}, Inamovible, f.name)
+	}
+	pascua := pascua(y)
+	add(diaDe(pascua.AddDate(0, 0, -48)), Inamovible, "Carnaval")
+	add(diaDe(pascua.AddDate(0, 0, -47)), Inamovible, "Carnaval")
+	add(diaDe(pascua.AddDate(0, 0, -2)), Inamovible, "Viernes Santo")
+	for _, f := range trasladables {
+		add(trasladar(dia{y, f.m, f.d}), Trasladable, f.name)
+	}
+	for _, d := range puentes {
+		if d.y == y {
+			add(d, Puente, nombrePuente)
+		}
+	}
+	return fs
+}
+
+// trasladar aplica la regla del artículo 6 de la Ley 27.399: los feriados
+// que caen martes o miércoles se trasladan al lunes anterior y los que caen
+// jueves o viernes al lunes siguiente.
+func trasladar(d dia) dia {
+	t := d.time()
+	switch t.Weekday() {
+	case time.Tuesday, time.Wednesday:
+		return diaDe(t.AddDate(0, 0, -int(t.Weekday()-time.Monday)))
+	case time.Thursday, time.Friday:
+		return diaDe(t.AddDate(0, 0, int(time.Saturday-t.Weekday())+2))
+	default:
+		return d
+	}
+}
+
+// pascua retorna el domingo de Pascua del año y según el calendario
+// gregoriano (algoritmo de Meeus/Jones/Butcher).
+func pascua(y int) time.Time {
+	a := y % 19
+	b, c := y/100, y%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(y, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
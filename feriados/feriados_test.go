@@ -0,0 +1,323 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package feriados
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lalloni/afip/periodo"
+)
+
+func Example() {
+	t := time.Date(2020, 11, 19, 10, 0, 0, 0, periodo.BuenosAires())
+	fmt.Println(NextBusinessDay(t).Format("2006-01-02 15:04"))
+	fmt.Println(AddBusinessDays(t, -3).Format("2006-01-02 15:04"))
+	// Output:
+	// 2020-11-20 10:00
+	// 2020-11-16 10:00
+}
+
+func ExampleCalendario_Feriados() {
+	for _, f := range New().Feriados(2021) {
+		fmt.Println(f.Fecha.Format(periodo.ISO), f.Tipo, f.Nombre)
+	}
+	// Output:
+	// 2021-01-01 Inamovible Año Nuevo
+	// 2021-02-15 Inamovible Carnaval
+	// 2021-02-16 Inamovible Carnaval
+	// 2021-03-24 Inamovible Día Nacional de la Memoria por la Verdad y la Justicia
+	// 2021-04-02 Inamovible Día del Veterano y de los Caídos en la Guerra de Malvinas; Viernes Santo
+	// 2021-05-01 Inamovible Día del Trabajador
+	// 2021-05-24 Puente Feriado con fines turísticos
+	// 2021-05-25 Inamovible Día de la Revolución de Mayo
+	// 2021-06-20 Inamovible Paso a la Inmortalidad del General Manuel Belgrano
+	// 2021-06-21 Trasladable Paso a la Inmortalidad del General Martín Miguel de Güemes
+	// 2021-07-09 Inamovible Día de la Independencia
+	// 2021-08-16 Trasladable Paso a la Inmortalidad del General José de San Martín
+	// 2021-10-08 Puente Feriado con fines turísticos
+	// 2021-10-11 Trasladable Día del Respeto a la Diversidad Cultural
+	// 2021-11-20 Trasladable Día de la Soberanía Nacional
+	// 2021-11-22 Puente Feriado con fines turísticos
+	// 2021-12-08 Inamovible Inmaculada Concepción de María
+	// 2021-12-25 Inamovible Navidad
+}
+
+func fecha(t *testing.T, s string) time.Time {
+	t.Helper()
+	f, err := time.ParseInLocation("2006-01-02", s, periodo.BuenosAires())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestPascua(t *testing.T) {
+	for _, s := range []string{"2017-04-16", "2019-04-21", "2020-04-12", "2021-04-04", "2024-03-31", "2025-04-20", "2038-04-25"} {
+		f := fecha(t, s)
+		if got := pascua(f.Year()).Format("2006-01-02"); got != s {
+			t.Errorf("pascua(%d) = %v, want %v", f.Year(), got, s)
+		}
+	}
+}
+
+func TestTrasladar(t *testing.T) {
+	tests := []struct{ fecha, want string }{
+		{"2020-11-16", "2020-11-16"}, // lunes
+		{"2021-08-17", "2021-08-16"}, // martes
+		{"2020-06-17", "2020-06-15"}, // miércoles
+		{"2021-06-17", "2021-06-21"}, // jueves
+		{"2020-11-20", "2020-11-23"}, // viernes
+		{"2021-11-20", "2021-11-20"}, // sábado
+		{"2019-11-17", "2019-11-17"}, // domingo
+	}
+	for _, test := range tests {
+		if got := trasladar(diaDe(fecha(t, test.fecha))).time().Format("2006-01-02"); got != test.want {
+			t.Errorf("trasladar(%v) = %v, want %v", test.fecha, got, test.want)
+		}
+	}
+}
+
+func TestFeriados(t *testing.T) {
+	tests := []struct {
+		y    int
+		want string
+	}{
+		{2018, "2018-01-01 2018-02-12 2018-02-13 2018-03-24 2018-03-30 2018-04-02 2018-04-30 2018-05-01 2018-05-25 " +
+			"2018-06-17 2018-06-20 2018-07-09 2018-08-20 2018-10-15 2018-11-19 2018-12-08 2018-12-24 2018-12-25 2018-12-31"},
+		{2019, "2019-01-01 2019-03-04 2019-03-05 2019-03-24 2019-04-02 2019-04-19 2019-05-01 2019-05-25 2019-06-17 " +
+			"2019-06-20 2019-07-08 2019-07-09 2019-08-17 2019-08-19 2019-10-12 2019-10-14 2019-11-18 2019-12-08 2019-12-25"},
+		{2020, "2020-01-01 2020-02-24 2020-02-25 2020-03-23 2020-03-24 2020-04-02 2020-04-10 2020-05-01 2020-05-25 " +
+			"2020-06-15 2020-06-20 2020-07-09 2020-07-10 2020-08-17 2020-10-12 2020-11-23 2020-12-07 2020-12-08 2020-12-25"},
+	}
+	for _, test := range tests {
+		var got []string
+		for _, f := range New().Feriados(test.y) {
+			got = append(got, f.Fecha.Format(periodo.ISO))
+		}
+		if strings.Join(got, " ") != test.want {
+			t.Errorf("Feriados(%d) = %v, want %v", test.y, got, test.want)
+		}
+	}
+	if fs := New().Feriados(2017); len(fs) != 0 {
+		t.Errorf("Feriados(2017) = %v, want none", fs)
+	}
+	if fs := New().Feriados(10000); len(fs) != 0 {
+		t.Errorf("Feriados(10000) = %v, want none", fs)
+	}
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	tests := []struct {
+		fecha string
+		want  bool
+	}{
+		{"2020-11-19", true},
+		{"2020-11-20", true},
+		{"2020-11-21", false}, // sábado
+		{"2020-11-22", false}, // domingo
+		{"2020-11-23", false}, // Día de la Soberanía Nacional
+		{"2020-12-07", false}, // puente
+		{"2018-04-30", false}, // puente
+		{"2018-12-31", false}, // puente
+		{"2019-08-19", false}, // puente
+		{"2019-10-14", false}, // puente
+		{"2020-04-10", false}, // Viernes Santo
+		{"2020-02-24", false}, // Carnaval
+	}
+	for _, test := range tests {
+		if got := IsBusinessDay(fecha(t, test.fecha)); got != test.want {
+			t.Errorf("IsBusinessDay(%v) = %v, want %v", test.fecha, got, test.want)
+		}
+		p, _ := periodo.ParseLayout(periodo.ISO, test.fecha)
+		if got, err := IsBusinessDayPeriodo(p); err != nil || got != test.want {
+			t.Errorf("IsBusinessDayPeriodo(%v) = %v, %v, want %v", p, got, err, test.want)
+		}
+	}
+	// El día es el de la zona horaria del instante.
+	utc := time.Date(2020, 11, 24, 1, 0, 0, 0, time.UTC)
+	if !IsBusinessDay(utc) || IsBusinessDay(utc.In(periodo.BuenosAires())) {
+		t.Error("IsBusinessDay() debe usar la zona horaria del instante")
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	tests := []struct {
+		fecha string
+		n     int
+		want  string
+	}{
+		{"2020-12-04", 0, "2020-12-04"},
+		{"2020-12-05", 0, "2020-12-05"},
+		{"2020-12-04", 1, "2020-12-09"},
+		{"2020-12-05", 1, "2020-12-09"},
+		{"2020-12-04", 2, "2020-12-10"},
+		{"2020-12-09", -1, "2020-12-04"},
+		{"2020-12-31", 1, "2021-01-04"},
+		{"2021-01-04", -2, "2020-12-30"},
+		{"2020-01-01", 244, "2020-12-31"},
+	}
+	for _, test := range tests {
+		if got := AddBusinessDays(fecha(t, test.fecha), test.n).Format("2006-01-02"); got != test.want {
+			t.Errorf("AddBusinessDays(%v, %d) = %v, want %v", test.fecha, test.n, got, test.want)
+		}
+		p, _ := periodo.ParseLayout(periodo.ISO, test.fecha)
+		if got, err := AddBusinessDaysPeriodo(p, test.n); err != nil || got.Format(periodo.ISO) != test.want {
+			t.Errorf("AddBusinessDaysPeriodo(%v, %d) = %v, %v, want %v", p, test.n, got, err, test.want)
+		}
+	}
+	if got := NextBusinessDay(fecha(t, "2020-11-20")).Format("2006-01-02"); got != "2020-11-24" {
+		t.Errorf("NextBusinessDay() = %v", got)
+	}
+	p, _ := periodo.NewDiario(2020, 11, 20)
+	if got, err := NextBusinessDayPeriodo(p); err != nil || got.String() != "20201124" {
+		t.Errorf("NextBusinessDayPeriodo() = %v, %v", got, err)
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	from := fecha(t, "2020-01-01")
+	if got := BusinessDaysBetween(from, fecha(t, "2020-12-31")); got != 244 {
+		t.Errorf("BusinessDaysBetween() = %d, want 244", got)
+	}
+	if got := BusinessDaysBetween(fecha(t, "2020-12-31"), from); got != -244 {
+		t.Errorf("BusinessDaysBetween() = %d, want -244", got)
+	}
+	for to := from; to.Year() == 2020; to = to.AddDate(0, 0, 1) {
+		n := BusinessDaysBetween(from, to)
+		if IsBusinessDay(to) && !AddBusinessDays(from, n).Equal(to) {
+			t.Errorf("AddBusinessDays(%v, BusinessDaysBetween(%v, %v)) = %v", from, from, to, AddBusinessDays(from, n))
+		}
+	}
+	a, _ := periodo.NewDiario(2020, 11, 19)
+	b, _ := periodo.NewDiario(2020, 11, 25)
+	if got, err := BusinessDaysBetweenPeriodo(a, b); err != nil || got != 3 {
+		t.Errorf("BusinessDaysBetweenPeriodo() = %v, %v, want 3", got, err)
+	}
+}
+
+func TestPeriodoErrors(t *testing.T) {
+	m, _ := periodo.NewMensual(2020, 11)
+	d, _ := periodo.NewDiario(2020, 11, 0)
+	ok, _ := periodo.NewDiario(2020, 11, 1)
+	for _, p := range []periodo.Periodo{m, d, {}} {
+		if _, err := IsBusinessDayPeriodo(p); err == nil {
+			t.Errorf("IsBusinessDayPeriodo(%v) expected error", p)
+		}
+		if _, err := AddBusinessDaysPeriodo(p, 1); err == nil {
+			t.Errorf("AddBusinessDaysPeriodo(%v) expected error", p)
+		}
+		if _, err := BusinessDaysBetweenPeriodo(ok, p); err == nil {
+			t.Errorf("BusinessDaysBetweenPeriodo(%v) expected error", p)
+		}
+	}
+	_, err := IsBusinessDayPeriodo(m)
+	if err == nil || err.Error() != "el período 202011 no es un período diario de un único día" {
+		t.Errorf("IsBusinessDayPeriodo() error = %v", err)
+	}
+	last, _ := periodo.NewDiario(periodo.MaxYear, 12, 31)
+	if _, err := AddBusinessDaysPeriodo(last, 1); err == nil {
+		t.Error("AddBusinessDaysPeriodo() expected error")
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	c := New()
+	p, _ := periodo.NewDiario(2020, 11, 19)
+	if err := c.Add(Feriado{Fecha: p, Tipo: Puente, Nombre: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if c.IsBusinessDay(fecha(t, "2020-11-19")) || !IsBusinessDay(fecha(t, "2020-11-19")) {
+		t.Error("Add() debe afectar sólo al calendario")
+	}
+	c.Remove(fecha(t, "2020-11-19"))
+	c.Remove(fecha(t, "2020-11-23"))
+	if !c.IsBusinessDay(fecha(t, "2020-11-19")) || !c.IsBusinessDay(fecha(t, "2020-11-23")) {
+		t.Error("Remove() debe quitar los feriados")
+	}
+	if f, ok := c.Feriado(fecha(t, "2020-12-25")); !ok || f.Nombre != "Navidad" || f.Tipo != Inamovible {
+		t.Errorf("Feriado() = %v, %v", f, ok)
+	}
+	if err := c.Add(Feriado{}); err == nil {
+		t.Error("Add() expected error")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	c := New()
+	if err := c.LoadFile("testdata/2020.csv"); err != nil {
+		t.Fatal(err)
+	}
+	if !c.IsBusinessDay(fecha(t, "2020-04-02")) || c.IsBusinessDay(fecha(t, "2020-03-31")) {
+		t.Error("LoadFile() no aplicó los cambios")
+	}
+	f, _ := c.Feriado(fecha(t, "2020-03-31"))
+	if f.Tipo != Inamovible || f.Nombre != "Día del Veterano y de los Caídos en la Guerra de Malvinas" {
+		t.Errorf("Feriado() = %v", f)
+	}
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"vacío", "", ""},
+		{"sin nombre", "2022-11-21,Puente\n", ""},
+		{"tipo", "2022-11-21,otro\n", `línea 1: tipo de feriado desconocido: "otro"`},
+		{"fecha", "21/11/2022,puente\n", `línea 1: formato de período diario ISO incorrecto: "21/11/2022"`},
+		{"día cero", "# x\n2022-11-00,puente\n", "línea 2: el período 20221100 no es un período diario de un único día"},
+		{"columnas", "2022-11-21\n", "línea 1: el registro tiene 1 columnas y se esperaban 2 o 3"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := New().Load(strings.NewReader(test.data))
+			if test.wantErr == "" && err != nil {
+				t.Errorf("Load() unexpected error = %v", err)
+			}
+			if test.wantErr != "" && (err == nil || err.Error() != test.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+	if err := c.LoadFile("testdata/inexistente.csv"); err == nil {
+		t.Error("LoadFile() expected error")
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	c := New()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			from := fecha(t, fmt.Sprintf("%d-01-01", 2018+i))
+			c.BusinessDaysBetween(from, from.AddDate(1, 0, 0))
+			c.Remove(from)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -1,6 +1,6 @@
 module github.com/lalloni/afip
 
-go 1.11
+go 1.13
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
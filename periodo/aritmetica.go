@@ -0,0 +1,274 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// unidad es la unidad en la que avanzan los períodos según su tipo y sus
+// componentes nulos.
+type unidad uint8
+
+const (
+	días unidad = iota
+	quincenas
+	meses
+	bimestres
+	trimestres
+	cuatrimestres
+	semestres
+	años
+)
+
+var unidades = []string{"días", "quincenas", "meses", "bimestres", "trimestres", "cuatrimestres", "semestres", "años"}
+
+func (u unidad) String() string {
+	return unidades[u]
+}
+
+// meses retorna la cantidad de meses de las unidades que abarcan meses
+// completos o 0 para las demás.
+func (u unidad) meses() int {
+	switch u {
+	case meses:
+		return 1
+	case bimestres:
+		return 2
+	case trimestres:
+		return 3
+	case cuatrimestres:
+		return 4
+	case semestres:
+		return 6
+	case años:
+		return 12
+	default:
+		return 0
+	}
+}
+
+// unidad retorna la unidad del período: los períodos diarios con día 0
+// representan un mes completo y avanzan de a meses, mientras que los
+// períodos diarios o mensuales con mes 0 representan un año completo y
+// avanzan de a años.
+func (p Periodo) unidad() unidad {
+	switch p.tipo {
+	case Diario, Mensual:
+		switch {
+		case p.m == 0:
+			return años
+		case p.tipo == Mensual || p.d == 0:
+			return meses
+		default:
+			return días
+		}
+	case Quincenal:
+		return quincenas
+	case Bimestral:
+		return bimestres
+	case Trimestral:
+		return trimestres
+	case Cuatrimestral:
+		return cuatrimestres
+	case Semestral:
+		return semestres
+	default:
+		return años
+	}
+}
+
+// index retorna la posición del período contando unidades desde el año 0,
+// para las unidades que no son días ni años.
+func (p Periodo) index() int {
+	month := int(p.y)*12 + int(p.m) - 1
+	if p.unidad() == quincenas {
+		return month*2 + int(p.Ordinal()) - 1
+	}
+	return month / p.unidad().meses()
+}
+
+// Add retorna el período que se encuentra n unidades después de p (o antes
+// si n es negativo), manteniendo su tipo.
+//
+// La unidad depende del tipo y de los componentes nulos de p: los períodos
+// diarios avanzan de a días, los mensuales y los diarios con día 0 avanzan
+// de a meses y los anuales y los que tienen mes 0 avanzan de a años, de
+// manera que, por ejemplo, el siguiente de 20201200 es 20210100 y el
+// siguiente de 202000 es 202100. Los demás tipos avanzan de a quincenas,
+// bimestres, trimestres, cuatrimestres o semestres según corresponda.
+//
+// Retorna error si el período resultante no es válido (por ejemplo, por
+// estar fuera del rango de años válidos).
+func (p Periodo) Add(n int) (Periodo, error) {
+	u := p.unidad()
+	outOfRange := func() error {
+		return errors.Errorf("el período %s desplazado %d %s está fuera de rango", p, n, u)
+	}
+	switch u {
+	case días:
+		t := time.Date(int(p.y), time.Month(p.m), int(p.d)+n, 0, 0, 0, 0, time.UTC)
+		if t.Year() < 0 {
+			return Periodo{}, outOfRange()
+		}
+		return p.with(uint(t.Year()), uint(t.Month()), uint(t.Day()))
+	case años:
+		y := int(p.y) + n
+		if y < 0 {
+			return Periodo{}, outOfRange()
+		}
+		return p.with(uint(y), p.m, p.d)
+	case quincenas:
+		i := p.index() + n
+		if i < 0 {
+			return Periodo{}, outOfRange()
+		}
+		return p.with(uint(i/24), uint(i%24/2+1), uint(1+i%2*15))
+	default:
+		i := p.index() + n
+		if i < 0 {
+			return Periodo{}, outOfRange()
+		}
+		month := i * u.meses()
+		return p.with(uint(month/12), uint(month%12+1), 0)
+	}
+}
+
+// with construye un período del mismo tipo que p con los componentes
+// suministrados validándolo.
+func (p Periodo) with(y, m, d uint) (Periodo, error) {
+	switch p.tipo {
+	case Diario:
+		return NewDiario(y, m, d)
+	case Mensual:
+		return NewMensual(y, m)
+	case Anual:
+		return NewAnual(y)
+	case Quincenal:
+		return NewQuincenal(y, m, 1+d/16)
+	case Bimestral, Trimestral, Cuatrimestral, Semestral:
+		return predeterminado.build(p.tipo, y, 1+(m-1)/mesesDe(p.tipo), 0)
+	default:
+		panic(periododesconocido)
+	}
+}
+
+// Next retorna el período siguiente a p (ver Add).
+func (p Periodo) Next() (Periodo, error) {
+	return p.Add(1)
+}
+
+// Prev retorna el período anterior a p (ver Add).
+func (p Periodo) Prev() (Periodo, error) {
+	return p.Add(-1)
+}
+
+// Sub retorna la cantidad de unidades (ver Add) que hay desde o hasta p, que
+// es negativa si p es anterior a o, de manera que o.Add(p.Sub(o)) == p.
+//
+// Retorna error si los períodos no son del mismo tipo o no avanzan en la
+// misma unidad (por ejemplo 20200300 y 20200315).
+func (p Periodo) Sub(o Periodo) (int, error) {
+	if p.tipo != o.tipo || p.unidad() != o.unidad() {
+		return 0, errors.Errorf("no es posible restar los períodos %s y %s porque no son del mismo tipo", p, o)
+	}
+	switch p.unidad() {
+	case días:
+		return int(p.days() - o.days()), nil
+	case años:
+		return int(p.y) - int(o.y), nil
+	default:
+		return p.index() - o.index(), nil
+	}
+}
+
+// days retorna la cantidad de días transcurridos desde el 1/1/1970 hasta el
+// período diario p.
+func (p Periodo) days() int64 {
+	return time.Date(int(p.y), time.Month(p.m), int(p.d), 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60)
+}
+
+// Between retorna la cantidad de unidades que hay desde from hasta to (ver
+// Sub).
+func Between(from, to Periodo) (int, error) {
+	return to.Sub(from)
+}
+
+// Compare compara p con o retornando -1 si p es anterior, 0 si son iguales y
+// +1 si p es posterior.
+//
+// Los períodos se ordenan por año, mes y día (siendo 0 anterior a cualquier
+// otro mes o día y considerando el primer mes o día en los tipos que abarcan
+// varios) y, en caso de coincidir, por la duración de su tipo (Diario,
+// Quincenal, Mensual, Bimestral, Trimestral, Cuatrimestral, Semestral y
+// luego Anual).
+func (p Periodo) Compare(o Periodo) int {
+	switch {
+	case p.y != o.y:
+		return sign(p.y, o.y)
+	case p.m != o.m:
+		return sign(p.m, o.m)
+	case p.d != o.d:
+		return sign(p.d, o.d)
+	case p.tipo != o.tipo:
+		return sign(duración[p.tipo], duración[o.tipo])
+	default:
+		return 0
+	}
+}
+
+// duración ordena los tipos de período según su duración.
+var duración = map[tipoPeriodo]uint{
+	Diario:        0,
+	Quincenal:     1,
+	Mensual:       2,
+	Bimestral:     3,
+	Trimestral:    4,
+	Cuatrimestral: 5,
+	Semestral:     6,
+	Anual:         7,
+}
+
+func sign(a, b uint) int {
+	if a < b {
+		return -1
+	}
+	return 1
+}
+
+// Before informa si p es anterior a o (ver Compare).
+func (p Periodo) Before(o Periodo) bool {
+	return p.Compare(o) < 0
+}
+
+// After informa si p es posterior a o (ver Compare).
+func (p Periodo) After(o Periodo) bool {
+	return p.Compare(o) > 0
+}
+
+// Equal informa si p y o son el mismo período.
+func (p Periodo) Equal(o Periodo) bool {
+	return p == o
+}
@@ -0,0 +1,192 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExamplePeriodo_Add() {
+	p, _ := Parse("201907")
+	q, _ := p.Add(-12)
+	fmt.Println(q)
+	n, _ := p.Next()
+	fmt.Println(n)
+	// Output:
+	// 201807
+	// 201908
+}
+
+func ExampleBetween() {
+	from, _ := Parse("201907")
+	to, _ := Parse("202003")
+	n, _ := Between(from, to)
+	fmt.Println(n)
+	// Output: 8
+}
+
+func mustParse(t *testing.T, v string) Periodo {
+	t.Helper()
+	p, err := Parse(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		p       string
+		n       int
+		want    string
+		wantErr bool
+	}{
+		{"20200315", 1, "20200316", false},
+		{"20200228", 1, "20200229", false},
+		{"20210228", 1, "20210301", false},
+		{"20201231", 1, "20210101", false},
+		{"20210101", -1, "20201231", false},
+		{"20200101", 366, "20210101", false},
+		{"20200300", 1, "20200400", false},
+		{"20201200", 1, "20210100", false},
+		{"20200100", -1, "20191200", false},
+		{"20200000", 1, "20210000", false},
+		{"202012", 1, "202101", false},
+		{"202001", -1, "201912", false},
+		{"201907", 8, "202003", false},
+		{"202003", -12, "201903", false},
+		{"202000", 1, "202100", false},
+		{"202000", -1, "201900", false},
+		{"2020", 5, "2025", false},
+		{"2020", 0, "2020", false},
+		{"99991231", 1, "", true},
+		{"10000101", -1, "", true},
+		{"999912", 1, "", true},
+		{"100001", -1, "", true},
+		{"9999", 1, "", true},
+		{"1000", -1001, "", true},
+		{"100001", -12001, "", true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%s%+d", test.p, test.n), func(t *testing.T) {
+			got, err := mustParse(t, test.p).Add(test.n)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Add() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && got.String() != test.want {
+				t.Errorf("Add() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNextPrev(t *testing.T) {
+	p := mustParse(t, "20200229")
+	n, err := p.Next()
+	if err != nil || n.String() != "20200301" {
+		t.Errorf("Next() = %v, %v", n, err)
+	}
+	q, err := n.Prev()
+	if err != nil || q != p {
+		t.Errorf("Prev() = %v, %v", q, err)
+	}
+}
+
+func TestSub(t *testing.T) {
+	tests := []struct {
+		p       string
+		o       string
+		want    int
+		wantErr bool
+	}{
+		{"20200301", "20200228", 2, false},
+		{"20210301", "20200301", 365, false},
+		{"20200228", "20200301", -2, false},
+		{"20200300", "20191100", 4, false},
+		{"20200000", "20100000", 10, false},
+		{"202003", "201907", 8, false},
+		{"202000", "201900", 1, false},
+		{"2020", "2030", -10, false},
+		{"202003", "2020", 0, true},
+		{"20200300", "20200301", 0, true},
+		{"202000", "202001", 0, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.p+"-"+test.o, func(t *testing.T) {
+			p, o := mustParse(t, test.p), mustParse(t, test.o)
+			got, err := p.Sub(o)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Sub() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if got != test.want {
+				t.Errorf("Sub() = %d, want %d", got, test.want)
+			}
+			if back, err := o.Add(got); err != nil || back != p {
+				t.Errorf("Add(Sub()) = %v, %v, want %v", back, err, p)
+			}
+			if b, _ := Between(o, p); b != got {
+				t.Errorf("Between() = %d, want %d", b, got)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		p    string
+		o    string
+		want int
+	}{
+		{"20200315", "20200315", 0},
+		{"20200315", "20200316", -1},
+		{"20200316", "20200315", 1},
+		{"20200300", "20200301", -1},
+		{"20200000", "20200101", -1},
+		{"20191231", "2020", -1},
+		{"202003", "20200301", -1},
+		{"20200300", "202003", -1},
+		{"202000", "2020", -1},
+		{"2021", "202012", 1},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.p+"-"+test.o, func(t *testing.T) {
+			p, o := mustParse(t, test.p), mustParse(t, test.o)
+			if got := p.Compare(o); got != test.want {
+				t.Errorf("Compare() = %d, want %d", got, test.want)
+			}
+			if got := o.Compare(p); got != -test.want {
+				t.Errorf("reverse Compare() = %d, want %d", got, -test.want)
+			}
+			if p.Before(o) != (test.want < 0) || p.After(o) != (test.want > 0) || p.Equal(o) != (test.want == 0) {
+				t.Errorf("Before/After/Equal inconsistent with Compare() = %d", test.want)
+			}
+		})
+	}
+}
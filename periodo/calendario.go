@@ -0,0 +1,44 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+// IsLeap informa si el año y es bisiesto según las reglas del calendario
+// gregoriano: los años divisibles por 4 son bisiestos excepto los divisibles
+// por 100 que no lo sean por 400.
+func IsLeap(y uint) bool {
+	return y%4 == 0 && (y%100 != 0 || y%400 == 0)
+}
+
+// DaysIn retorna la cantidad de días del mes m del año y considerando años
+// bisiestos.
+//
+// Si m no está dentro del rango [1,12] retorna 0.
+func DaysIn(y, m uint) uint {
+	if m < 1 || m > maxMonth {
+		return 0
+	}
+	if m == 2 && IsLeap(y) {
+		return 29
+	}
+	return days[m]
+}
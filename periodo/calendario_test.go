@@ -0,0 +1,85 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsLeap(t *testing.T) {
+	tests := []struct {
+		y    uint
+		want bool
+	}{
+		{1900, false},
+		{2000, true},
+		{2001, false},
+		{2004, true},
+		{2100, false},
+		{2400, true},
+	}
+	for _, test := range tests {
+		if got := IsLeap(test.y); got != test.want {
+			t.Errorf("IsLeap(%d) = %v, want %v", test.y, got, test.want)
+		}
+	}
+	for y := MinYear; y <= MaxYear; y++ {
+		want := time.Date(int(y), time.February, 29, 0, 0, 0, 0, time.UTC).Month() == time.February
+		if got := IsLeap(y); got != want {
+			t.Errorf("IsLeap(%d) = %v, want %v", y, got, want)
+		}
+	}
+}
+
+func TestDaysIn(t *testing.T) {
+	for y := MinYear; y <= MaxYear; y++ {
+		for m := uint(1); m <= 12; m++ {
+			// el día 0 del mes siguiente es el último del mes
+			want := uint(time.Date(int(y), time.Month(m+1), 0, 0, 0, 0, 0, time.UTC).Day())
+			if got := DaysIn(y, m); got != want {
+				t.Errorf("DaysIn(%d, %d) = %d, want %d", y, m, got, want)
+			}
+		}
+	}
+	if got := DaysIn(2000, 0); got != 0 {
+		t.Errorf("DaysIn(2000, 0) = %d, want 0", got)
+	}
+	if got := DaysIn(2000, 13); got != 0 {
+		t.Errorf("DaysIn(2000, 13) = %d, want 0", got)
+	}
+}
+
+func TestCheckPeriodoDiarioTime(t *testing.T) {
+	for y := MinYear; y <= MaxYear; y++ {
+		for m := uint(1); m <= 12; m++ {
+			for d := uint(1); d <= 32; d++ {
+				tm := time.Date(int(y), time.Month(m), int(d), 0, 0, 0, 0, time.UTC)
+				want := tm.Day() == int(d)
+				if got := CheckPeriodoDiario(y, m, d); got != want {
+					t.Fatalf("CheckPeriodoDiario(%d, %d, %d) = %v, want %v", y, m, d, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// numerico informa si p se codifica como un número en JSON y SQL. Los
+// períodos bimestrales, trimestrales, cuatrimestrales y semestrales no lo
+// hacen porque su forma numérica no puede distinguirse de la de los
+// mensuales.
+func (p Periodo) numerico() bool {
+	return mesesDe(p.tipo) == 0
+}
+
+// MarshalText implementa encoding.TextMarshaler usando la forma retornada
+// por String.
+//
+// El valor cero se codifica como un texto vacío.
+func (p Periodo) MarshalText() ([]byte, error) {
+	if p.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implementa encoding.TextUnmarshaler aceptando las formas
+// soportadas por Parse.
+//
+// Un texto vacío se decodifica como el valor cero.
+func (p *Periodo) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = Periodo{}
+		return nil
+	}
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// MarshalJSON implementa json.Marshaler codificando p como un número JSON
+// (ver Uint) o, si es bimestral, trimestral, cuatrimestral o semestral, como
+// un string JSON con la forma retornada por String.
+//
+// El valor cero se codifica como null.
+func (p Periodo) MarshalJSON() ([]byte, error) {
+	switch {
+	case p.IsZero():
+		return []byte("null"), nil
+	case p.numerico():
+		return strconv.AppendUint(nil, uint64(p.Uint()), 10), nil
+	default:
+		return json.Marshal(p.String())
+	}
+}
+
+// UnmarshalJSON implementa json.Unmarshaler aceptando tanto un número JSON
+// como un string JSON en cualquiera de las formas soportadas por Parse.
+//
+// Como es usual, null deja a p sin cambios.
+func (p *Periodo) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return errors.Wrap(err, "decodificando período")
+		}
+		return p.UnmarshalText([]byte(s))
+	}
+	v, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// Scan implementa sql.Scanner aceptando valores enteros, string y []byte en
+// las formas soportadas por Parse y valores time.Time (por ejemplo de
+// columnas DATE), que se obtienen como períodos diarios en la zona horaria
+// del valor.
+//
+// NULL se obtiene como el valor cero.
+func (p *Periodo) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = Periodo{}
+		return nil
+	case int64:
+		if v < 0 {
+			return errors.Errorf("formato de período incorrecto: %d", v)
+		}
+		return p.UnmarshalText(strconv.AppendInt(nil, v, 10))
+	case string:
+		return p.UnmarshalText([]byte(v))
+	case []byte:
+		return p.UnmarshalText(v)
+	case time.Time:
+		d, err := FromTime(v, Diario, v.Location())
+		if err != nil {
+			return err
+		}
+		*p = d
+		return nil
+	default:
+		return errors.Errorf("no es posible obtener un período desde %T", src)
+	}
+}
+
+// Value implementa driver.Valuer retornando p como un int64 (ver Uint) o, si
+// es bimestral, trimestral, cuatrimestral o semestral, como un string con la
+// forma retornada por String.
+//
+// El valor cero se retorna como NULL.
+func (p Periodo) Value() (driver.Value, error) {
+	switch {
+	case p.IsZero():
+		return nil, nil
+	case p.numerico():
+		return int64(p.Uint()), nil
+	default:
+		return p.String(), nil
+	}
+}
+
+// Fecha es un Periodo que se almacena en columnas DATE como la fecha de su
+// primer día. Se obtiene con Periodo.Date.
+//
+// Como Scan obtiene los valores time.Time como períodos diarios, sólo los
+// períodos diarios se recuperan sin cambios: los demás se recuperan como
+// el período diario de su primer día.
+type Fecha struct {
+	Periodo
+}
+
+// Date retorna p como una Fecha para almacenarlo en columnas DATE.
+func (p Periodo) Date() Fecha {
+	return Fecha{p}
+}
+
+// Value implementa driver.Valuer retornando el primer instante del período
+// en UTC (ver Start).
+//
+// El valor cero se retorna como NULL.
+func (f Fecha) Value() (driver.Value, error) {
+	if f.IsZero() {
+		return nil, nil
+	}
+	return f.Start(time.UTC), nil
+}
+
+// Set implementa flag.Value aceptando las formas soportadas por Parse.
+func (p *Periodo) Set(s string) error {
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// MarshalText implementa encoding.TextMarshaler usando la forma retornada
+// por String.
+//
+// El valor cero se codifica como un texto vacío.
+func (r Rango) MarshalText() ([]byte, error) {
+	if r.from.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implementa encoding.TextUnmarshaler aceptando las formas
+// soportadas por ParseRango.
+//
+// Un texto vacío se decodifica como el valor cero.
+func (r *Rango) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = Rango{}
+		return nil
+	}
+	return r.Set(string(text))
+}
+
+// Set implementa flag.Value aceptando las formas soportadas por ParseRango.
+func (r *Rango) Set(s string) error {
+	v, err := ParseRango(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
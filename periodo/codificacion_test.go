@@ -0,0 +1,251 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func ExamplePeriodo_MarshalJSON() {
+	type declaracion struct {
+		Periodo   Periodo `json:"periodo"`
+		Trimestre Periodo `json:"trimestre"`
+		Anterior  Periodo `json:"anterior"`
+	}
+	m, _ := NewMensual(2020, 3)
+	t, _ := NewTrimestral(2020, 1)
+	bs, _ := json.Marshal(declaracion{Periodo: m, Trimestre: t})
+	fmt.Println(string(bs))
+	// Output:
+	// {"periodo":202003,"trimestre":"2020T1","anterior":null}
+}
+
+func TestPeriodoJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr string
+	}{
+		{"número mensual", "202003", "202003", ""},
+		{"número diario", "20200315", "20200315", ""},
+		{"número anual", "2020", "2020", ""},
+		{"número quincenal", "2020032", "2020032", ""},
+		{"string mensual", `"202003"`, "202003", ""},
+		{"string trimestral", `"2020T1"`, "2020T1", ""},
+		{"espacios", " 202003 ", "202003", ""},
+		{"mes inválido", "202013", "", "período mensual 202013 inválido: el mes 13 está fuera del rango [0,12]"},
+		{"string inválido", `"2020T5"`, "", "período trimestral 2020T5 inválido: el trimestre 5 está fuera del rango [1,4]"},
+		{"negativo", "-202003", "", `formato de período incorrecto: "-202003"`},
+		{"decimal", "2020.5", "", `formato de período incorrecto: "2020.5"`},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var got Periodo
+			err := json.Unmarshal([]byte(test.data), &got)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Errorf("Unmarshal() error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal() unexpected error = %v", err)
+			}
+			if got.String() != test.want {
+				t.Errorf("Unmarshal() = %v, want %v", got, test.want)
+			}
+			bs, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal() unexpected error = %v", err)
+			}
+			var back Periodo
+			if err := json.Unmarshal(bs, &back); err != nil || !back.Equal(got) {
+				t.Errorf("round trip %s = %v (%v), want %v", bs, back, err, got)
+			}
+		})
+	}
+	t.Run("null", func(t *testing.T) {
+		p := mustParse(t, "202003")
+		if err := json.Unmarshal([]byte("null"), &p); err != nil || p.String() != "202003" {
+			t.Errorf("Unmarshal(null) = %v, %v, want unchanged", p, err)
+		}
+		bs, _ := json.Marshal(Periodo{})
+		if string(bs) != "null" {
+			t.Errorf("Marshal(Periodo{}) = %s, want null", bs)
+		}
+	})
+}
+
+func TestPeriodoText(t *testing.T) {
+	var m map[Periodo]int
+	if err := json.Unmarshal([]byte(`{"202003":1,"2020S2":2}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m[mustParse(t, "202003")] != 1 || m[mustParse(t, "2020S2")] != 2 {
+		t.Errorf("Unmarshal() = %v", m)
+	}
+	p := mustParse(t, "2020")
+	if err := p.UnmarshalText(nil); err != nil || !p.IsZero() {
+		t.Errorf("UnmarshalText(nil) = %v, %v, want zero", p, err)
+	}
+	if bs, err := p.MarshalText(); err != nil || len(bs) != 0 {
+		t.Errorf("MarshalText() = %q, %v, want empty", bs, err)
+	}
+	if err := p.UnmarshalText([]byte("2020C4")); err == nil {
+		t.Error("UnmarshalText() expected error")
+	}
+}
+
+func TestPeriodoSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    string
+		wantErr bool
+	}{
+		{"int64", int64(202003), "202003", false},
+		{"string", "2020B3", "2020B3", false},
+		{"bytes", []byte("20200315"), "20200315", false},
+		{"date", time.Date(2020, 3, 15, 0, 0, 0, 0, time.UTC), "20200315", false},
+		{"nulo", nil, "", false},
+		{"int64 inválido", int64(202013), "", true},
+		{"negativo", int64(-1), "", true},
+		{"date inválido", time.Date(200, 3, 15, 0, 0, 0, 0, time.UTC), "", true},
+		{"float", 2020.0, "", true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var got Periodo
+			err := got.Scan(test.src)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if test.want == "" {
+				if !got.IsZero() {
+					t.Errorf("Scan() = %v, want zero", got)
+				}
+				return
+			}
+			if got.String() != test.want {
+				t.Errorf("Scan() = %v, want %v", got, test.want)
+			}
+		})
+	}
+	values := []struct {
+		p    Periodo
+		want driver.Value
+	}{
+		{Periodo{}, nil},
+		{mustParse(t, "202003"), int64(202003)},
+		{mustParse(t, "2020032"), int64(2020032)},
+		{mustParse(t, "2020T2"), "2020T2"},
+	}
+	for _, v := range values {
+		if got, err := v.p.Value(); err != nil || got != v.want {
+			t.Errorf("Value(%v) = %v, %v, want %v", v.p, got, err, v.want)
+		}
+	}
+}
+
+func TestFecha(t *testing.T) {
+	tests := []struct {
+		p    string
+		want string
+	}{
+		{"20200316", "20200316"},
+		{"202003", "20200301"},
+		{"2020T2", "20200401"},
+	}
+	for _, test := range tests {
+		v, err := mustParse(t, test.p).Date().Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tm, ok := v.(time.Time); !ok || tm.Location() != time.UTC || tm.Hour() != 0 {
+			t.Errorf("Date().Value() = %#v, want a UTC time.Time", v)
+		}
+		var got Fecha
+		if err := got.Scan(v); err != nil || got.String() != test.want || got.Tipo() != Diario {
+			t.Errorf("Scan(%v) = %v, %v, want %v", v, got, err, test.want)
+		}
+	}
+	if v, err := (Periodo{}).Date().Value(); v != nil || err != nil {
+		t.Errorf("Date().Value() = %v, %v, want nil", v, err)
+	}
+	var got Fecha
+	if err := got.Scan(nil); err != nil || !got.IsZero() {
+		t.Errorf("Scan(nil) = %v, %v", got, err)
+	}
+}
+
+func TestFlag(t *testing.T) {
+	var p Periodo
+	var r Rango
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Var(&p, "periodo", "período")
+	fs.Var(&r, "rango", "rango")
+	if err := fs.Parse([]string{"-periodo", "2020T2", "-rango", "201901-201912"}); err != nil {
+		t.Fatal(err)
+	}
+	if p.String() != "2020T2" || r.String() != "201901-201912" {
+		t.Errorf("Parse() = %v %v", p, r)
+	}
+	if err := fs.Parse([]string{"-periodo", "202013"}); err == nil {
+		t.Error("Parse() expected error")
+	}
+}
+
+func TestRangoText(t *testing.T) {
+	var got struct {
+		Rango Rango `json:"rango"`
+	}
+	if err := json.Unmarshal([]byte(`{"rango":"2019T1-2020T4"}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Rango.Len() != 8 {
+		t.Errorf("Unmarshal() = %v", got.Rango)
+	}
+	bs, err := json.Marshal(got)
+	if err != nil || string(bs) != `{"rango":"2019T1-2020T4"}` {
+		t.Errorf("Marshal() = %s, %v", bs, err)
+	}
+	if err := json.Unmarshal([]byte(`{"rango":"202001-2020"}`), &got); err == nil {
+		t.Error("Unmarshal() expected error")
+	}
+	if bs, _ := (Rango{}).MarshalText(); len(bs) != 0 {
+		t.Errorf("MarshalText() = %q, want empty", bs)
+	}
+}
@@ -0,0 +1,156 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import "github.com/pkg/errors"
+
+// dia es un día del calendario usado para comparar los límites de los
+// períodos.
+type dia struct {
+	y, m, d uint
+}
+
+func (a dia) before(b dia) bool {
+	switch {
+	case a.y != b.y:
+		return a.y < b.y
+	case a.m != b.m:
+		return a.m < b.m
+	default:
+		return a.d < b.d
+	}
+}
+
+// first retorna el primer día que abarca el período.
+func (p Periodo) first() dia {
+	f := dia{p.y, p.m, p.d}
+	if f.m == 0 {
+		f.m = 1
+	}
+	if f.d == 0 {
+		f.d = 1
+	}
+	return f
+}
+
+// last retorna el último día que abarca el período.
+func (p Periodo) last() dia {
+	switch u := p.unidad(); u {
+	case días:
+		return dia{p.y, p.m, p.d}
+	case quincenas:
+		if p.d == 1 {
+			return dia{p.y, p.m, 15}
+		}
+		return dia{p.y, p.m, DaysIn(p.y, p.m)}
+	case años:
+		return dia{p.y, maxMonth, DaysIn(p.y, maxMonth)}
+	default:
+		m := p.m + uint(u.meses()) - 1
+		return dia{p.y, m, DaysIn(p.y, m)}
+	}
+}
+
+// at retorna el período de tipo t que contiene al día d.
+func at(t tipoPeriodo, d dia) Periodo {
+	switch t {
+	case Diario:
+		return Periodo{tipo: t, y: d.y, m: d.m, d: d.d}
+	case Quincenal:
+		if d.d < 16 {
+			return Periodo{tipo: t, y: d.y, m: d.m, d: 1}
+		}
+		return Periodo{tipo: t, y: d.y, m: d.m, d: 16}
+	case Mensual:
+		return Periodo{tipo: t, y: d.y, m: d.m}
+	case Anual:
+		return Periodo{tipo: t, y: d.y}
+	case Bimestral, Trimestral, Cuatrimestral, Semestral:
+		k := mesesDe(t)
+		return Periodo{tipo: t, y: d.y, m: (d.m-1)/k*k + 1}
+	default:
+		panic(periododesconocido)
+	}
+}
+
+// In retorna el período de tipo t que contiene a p, por ejemplo el trimestre
+// de un mes o el semestre de un día.
+//
+// Los períodos diarios con día 0 se consideran como el mes completo y los
+// diarios y mensuales con mes 0 como el año completo.
+//
+// Retorna error si no existe un período de tipo t que contenga a p, como
+// ocurre con el segundo bimestre (marzo y abril) y los trimestres, o si t es
+// de menor duración que p.
+func (p Periodo) In(t tipoPeriodo) (Periodo, error) {
+	if t == p.tipo {
+		return p, nil
+	}
+	q := at(t, p.first())
+	if q.last().before(p.last()) {
+		return Periodo{}, errors.Errorf("el período %s no está contenido en ningún período %s", p, t)
+	}
+	return q, nil
+}
+
+// Split retorna los períodos de tipo t que componen a p en orden, por
+// ejemplo los meses de un trimestre o las quincenas de un semestre.
+//
+// Los períodos diarios con día 0 se consideran como el mes completo y los
+// diarios y mensuales con mes 0 como el año completo. Si t es el tipo de p
+// retorna sólo p.
+//
+// Retorna error si p no puede componerse con períodos de tipo t, como ocurre
+// con los trimestres y los bimestres, si t es de mayor duración que p o si
+// alguno de los períodos resultantes no es válido según el validador
+// predeterminado (ver Add), como ocurre con los construidos con un
+// Validator con otro rango de años.
+func (p Periodo) Split(t tipoPeriodo) ([]Periodo, error) {
+	if t == p.tipo {
+		return []Periodo{p}, nil
+	}
+	q := at(t, p.first())
+	if q.first() != p.first() {
+		return nil, notComposable(p, t)
+	}
+	last := p.last()
+	var ps []Periodo
+	for {
+		ps = append(ps, q)
+		ql := q.last()
+		if ql == last {
+			return ps, nil
+		}
+		if last.before(ql) {
+			return nil, notComposable(p, t)
+		}
+		var err error
+		if q, err = q.Next(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func notComposable(p Periodo, t tipoPeriodo) error {
+	return errors.Errorf("el período %s no puede componerse con períodos de tipo %s", p, t)
+}
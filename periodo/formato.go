@@ -0,0 +1,402 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Layout identifica una de las formas textuales en las que pueden
+// formatearse y parsearse los períodos (ver Periodo.Format y ParseLayout).
+//
+// La siguiente tabla muestra cómo se escriben en cada una de ellas los
+// períodos diario, quincenal, mensual y trimestral que contienen al 15 de
+// marzo de 2020. Los períodos anuales se escriben siempre con la forma YYYY.
+//
+//	Compact   20200315       2020031                       202003       2020T1
+//	ISO       2020-03-15     2020-03-Q1                    2020-03      2020-T1
+//	SlashISO  2020/03/15     2020/03/Q1                    2020/03      2020/T1
+//	Slash     15/03/2020     Q1/03/2020                    03/2020      T1/2020
+//	Long      15 marzo 2020  primera quincena marzo 2020   marzo 2020   primer trimestre 2020
+//	Short     15 mar 2020    Q1 mar 2020                   mar 2020     T1 2020
+//
+// Los períodos bimestrales, cuatrimestrales y semestrales se escriben como
+// los trimestrales usando las letras B, C y S o las palabras bimestre,
+// cuatrimestre y semestre respectivamente.
+//
+// Los meses y días 0 de los períodos diarios y mensuales se escriben como 00
+// en todas las formas, incluidas Long y Short.
+type Layout uint8
+
+const (
+	// Compact es la forma retornada por Periodo.String y aceptada por Parse.
+	Compact Layout = iota
+	// ISO es la forma con año, mes y día separados por guiones.
+	ISO
+	// SlashISO es como ISO pero separando con barras.
+	SlashISO
+	// Slash es la forma con día, mes y año separados por barras.
+	Slash
+	// Long es la forma con los nombres completos de los meses en castellano.
+	Long
+	// Short es la forma con los nombres abreviados de los meses en
+	// castellano.
+	Short
+)
+
+func (l Layout) String() string {
+	switch l {
+	case Compact:
+		return "Compact"
+	case ISO:
+		return "ISO"
+	case SlashISO:
+		return "SlashISO"
+	case Slash:
+		return "Slash"
+	case Long:
+		return "Long"
+	case Short:
+		return "Short"
+	default:
+		return "Layout(" + strconv.Itoa(int(l)) + ")"
+	}
+}
+
+// Las plantillas describen cada forma con los siguientes elementos, siendo
+// literal el resto del texto:
+//
+//	{Y}    año con 4 dígitos
+//	{M}    mes con 2 dígitos
+//	{D}    día con 2 dígitos
+//	{Q}    número de quincena
+//	{N}    número de bimestre, trimestre, cuatrimestre o semestre
+//	{X}    letra del tipo de período (ver letra)
+//	{MMMM} nombre del mes
+//	{MMM}  nombre abreviado del mes
+//	{QQQQ} ordinal de la quincena
+//	{NNNN} ordinal del bimestre, trimestre, cuatrimestre o semestre
+//	{TTTT} nombre de las partes del año (ver nombre)
+//
+// En los períodos subanuales {N} y {NNNN} se usa el componente n y {Q} y
+// {QQQQ} el componente d (ver build).
+var plantillas = map[Layout]map[tipoPeriodo]string{
+	Compact: {
+		Diario:    "{Y}{M}{D}",
+		Quincenal: "{Y}{M}{Q}",
+		Mensual:   "{Y}{M}",
+		Bimestral: "{Y}{X}{N}",
+		Anual:     "{Y}",
+	},
+	ISO: {
+		Diario:    "{Y}-{M}-{D}",
+		Quincenal: "{Y}-{M}-Q{Q}",
+		Mensual:   "{Y}-{M}",
+		Bimestral: "{Y}-{X}{N}",
+		Anual:     "{Y}",
+	},
+	SlashISO: {
+		Diario:    "{Y}/{M}/{D}",
+		Quincenal: "{Y}/{M}/Q{Q}",
+		Mensual:   "{Y}/{M}",
+		Bimestral: "{Y}/{X}{N}",
+		Anual:     "{Y}",
+	},
+	Slash: {
+		Diario:    "{D}/{M}/{Y}",
+		Quincenal: "Q{Q}/{M}/{Y}",
+		Mensual:   "{M}/{Y}",
+		Bimestral: "{X}{N}/{Y}",
+		Anual:     "{Y}",
+	},
+	Long: {
+		Diario:    "{D} {MMMM} {Y}",
+		Quincenal: "{QQQQ} quincena {MMMM} {Y}",
+		Mensual:   "{MMMM} {Y}",
+		Bimestral: "{NNNN} {TTTT} {Y}",
+		Anual:     "{Y}",
+	},
+	Short: {
+		Diario:    "{D} {MMM} {Y}",
+		Quincenal: "Q{Q} {MMM} {Y}",
+		Mensual:   "{MMM} {Y}",
+		Bimestral: "{X}{N} {Y}",
+		Anual:     "{Y}",
+	},
+}
+
+// tipos es el orden en el que ParseLayout prueba los tipos de período.
+var tipos = []tipoPeriodo{Diario, Quincenal, Mensual, Bimestral, Trimestral, Cuatrimestral, Semestral, Anual}
+
+var (
+	mesesLargos       = []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}
+	mesesCortos       = []string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"}
+	ordinales         = []string{"primer", "segundo", "tercer", "cuarto", "quinto", "sexto"}
+	ordinalesQuincena = []string{"primera", "segunda"}
+
+	// alternativas contiene otras formas aceptadas al parsear los nombres.
+	alternativas = map[string][]string{
+		"septiembre": {"setiembre"},
+		"sep":        {"set"},
+		"primer":     {"primero", "1er", "1ro", "1°"},
+		"segundo":    {"2do", "2°"},
+		"tercer":     {"tercero", "3er", "3ro", "3°"},
+		"cuarto":     {"4to", "4°"},
+		"quinto":     {"5to", "5°"},
+		"sexto":      {"6to", "6°"},
+		"primera":    {"1ra", "1°"},
+		"segunda":    {"2da", "2°"},
+	}
+)
+
+// pieza es un elemento de una plantilla: un literal o un campo.
+type pieza struct {
+	literal string
+	campo   string
+}
+
+// piezas descompone la plantilla del tipo t en la forma l.
+func piezas(l Layout, t tipoPeriodo) []pieza {
+	formas, ok := plantillas[l]
+	if !ok {
+		return nil
+	}
+	if mesesDe(t) > 0 {
+		t = Bimestral
+	}
+	s := formas[t]
+	var ps []pieza
+	for s != "" {
+		i := strings.IndexByte(s, '{')
+		switch {
+		case i < 0:
+			ps, s = append(ps, pieza{literal: s}), ""
+		case i > 0:
+			ps, s = append(ps, pieza{literal: s[:i]}), s[i:]
+		default:
+			j := strings.IndexByte(s, '}')
+			ps, s = append(ps, pieza{campo: s[1:j]}), s[j+1:]
+		}
+	}
+	return ps
+}
+
+// Format retorna la forma textual de p según el layout l.
+//
+// El valor cero se formatea como un texto vacío. Hace panic si l no es uno
+// de los layouts definidos en este paquete.
+func (p Periodo) Format(l Layout) string {
+	ps := piezas(l, p.tipo)
+	if ps == nil {
+		panic(fmt.Sprintf("formato de período desconocido: %v", l))
+	}
+	if p.IsZero() {
+		return ""
+	}
+	t, y, n, d := p.components()
+	var b strings.Builder
+	for _, pz := range ps {
+		switch pz.campo {
+		case "":
+			b.WriteString(pz.literal)
+		case "Y":
+			fmt.Fprintf(&b, "%04d", y)
+		case "M":
+			fmt.Fprintf(&b, "%02d", n)
+		case "D":
+			fmt.Fprintf(&b, "%02d", d)
+		case "Q":
+			fmt.Fprintf(&b, "%d", d)
+		case "N":
+			fmt.Fprintf(&b, "%d", n)
+		case "X":
+			b.WriteByte(letra(t))
+		case "MMMM":
+			b.WriteString(mes(mesesLargos, n))
+		case "MMM":
+			b.WriteString(mes(mesesCortos, n))
+		case "QQQQ":
+			b.WriteString(ordinalesQuincena[d-1])
+		case "NNNN":
+			b.WriteString(ordinales[n-1])
+		case "TTTT":
+			b.WriteString(nombre(t))
+		}
+	}
+	return b.String()
+}
+
+func mes(nombres []string, m uint) string {
+	if m == 0 {
+		return "00"
+	}
+	return nombres[m-1]
+}
+
+// ParseLayout intenta extraer un período fiscal de v escrito en la forma l
+// infiriendo su tipo.
+//
+// Ignora la diferencia entre mayúsculas y minúsculas, los espacios al
+// principio y al final y los espacios repetidos. En las formas Slash, ISO y
+// SlashISO los meses y días pueden escribirse con un único dígito y en Long
+// y Short se aceptan algunas variantes de los nombres, como "setiembre" o
+// "1er trimestre".
+func ParseLayout(l Layout, v string) (Periodo, error) {
+	return predeterminado.ParseLayout(l, v)
+}
+
+// ParseLayoutTipo es como ParseLayout pero sólo acepta períodos del tipo t.
+func ParseLayoutTipo(l Layout, t tipoPeriodo, v string) (Periodo, error) {
+	return predeterminado.ParseLayoutTipo(l, t, v)
+}
+
+// ParseLayout es como la función ParseLayout del paquete pero valida el
+// período con las reglas de v.
+func (v Validator) ParseLayout(l Layout, s string) (Periodo, error) {
+	if _, ok := plantillas[l]; !ok {
+		return Periodo{}, errors.Errorf("formato de período desconocido: %v", l)
+	}
+	for _, t := range tipos {
+		if y, n, d, ok := match(piezas(l, t), t, s); ok {
+			return v.build(t, y, n, d)
+		}
+	}
+	return Periodo{}, errors.Errorf("formato de período %v incorrecto: %q", l, s)
+}
+
+// ParseLayoutTipo es como la función ParseLayoutTipo del paquete pero valida
+// el período con las reglas de v.
+func (v Validator) ParseLayoutTipo(l Layout, t tipoPeriodo, s string) (Periodo, error) {
+	if _, ok := plantillas[l]; !ok {
+		return Periodo{}, errors.Errorf("formato de período desconocido: %v", l)
+	}
+	y, n, d, ok := match(piezas(l, t), t, s)
+	if !ok {
+		return Periodo{}, errors.Errorf("formato de período %s %v incorrecto: %q", strings.ToLower(t.String()), l, s)
+	}
+	return v.build(t, y, n, d)
+}
+
+// match intenta extraer de s los componentes (ver build) de un período de
+// tipo t escrito según las piezas ps.
+func match(ps []pieza, t tipoPeriodo, s string) (y, n, d uint, ok bool) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	for i, pz := range ps {
+		var u uint
+		switch pz.campo {
+		case "":
+			if !strings.HasPrefix(s, strings.ToLower(pz.literal)) {
+				return 0, 0, 0, false
+			}
+			s = s[len(pz.literal):]
+			continue
+		case "Y":
+			u, s, ok = numero(s, 4, false)
+			y = u
+		case "M", "D":
+			// Se aceptan meses y días de un dígito sólo cuando están
+			// separados de los demás campos, porque de lo contrario serían
+			// ambiguos.
+			u, s, ok = numero(s, 2, separado(ps, i-1) && separado(ps, i+1))
+			if pz.campo == "M" {
+				n = u
+			} else {
+				d = u
+			}
+		case "Q":
+			u, s, ok = numero(s, 1, false)
+			d = u
+		case "N":
+			u, s, ok = numero(s, 1, false)
+			n = u
+		case "X":
+			ok = strings.HasPrefix(s, strings.ToLower(string(letra(t))))
+			if ok {
+				s = s[1:]
+			}
+		case "MMMM":
+			if n, s, ok = nombrado(s, mesesLargos); !ok {
+				n, s, ok = numero(s, 2, false)
+				ok = ok && n == 0
+			}
+		case "MMM":
+			if n, s, ok = nombrado(s, mesesCortos); !ok {
+				n, s, ok = numero(s, 2, false)
+				ok = ok && n == 0
+			}
+		case "QQQQ":
+			d, s, ok = nombrado(s, ordinalesQuincena)
+		case "NNNN":
+			n, s, ok = nombrado(s, ordinales)
+		case "TTTT":
+			ok = strings.HasPrefix(s, nombre(t))
+			if ok {
+				s = s[len(nombre(t)):]
+			}
+		}
+		if !ok {
+			return 0, 0, 0, false
+		}
+	}
+	return y, n, d, s == ""
+}
+
+// separado informa si la pieza i de ps es un literal o está fuera de ps.
+func separado(ps []pieza, i int) bool {
+	return i < 0 || i >= len(ps) || ps[i].campo == ""
+}
+
+// numero extrae de s un número de exactamente ancho dígitos o, si flexible,
+// de entre 1 y ancho dígitos.
+func numero(s string, ancho int, flexible bool) (uint, string, bool) {
+	i := 0
+	for i < len(s) && i < ancho && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || i < ancho && !flexible {
+		return 0, s, false
+	}
+	n, _ := strconv.Atoi(s[:i])
+	return uint(n), s[i:], true
+}
+
+// nombrado extrae de s uno de los nombres (o alguna de sus alternativas)
+// retornando su posición contando desde 1.
+func nombrado(s string, nombres []string) (uint, string, bool) {
+	var (
+		n     uint
+		largo int
+	)
+	for i, nombre := range nombres {
+		for _, a := range append([]string{nombre}, alternativas[nombre]...) {
+			if len(a) > largo && strings.HasPrefix(s, a) {
+				n, largo = uint(i+1), len(a)
+			}
+		}
+	}
+	return n, s[largo:], n > 0
+}
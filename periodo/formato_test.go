@@ -0,0 +1,175 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleParseLayout() {
+	for _, v := range []string{"03/2020", "15/03/2020", "T1/2020"} {
+		p, _ := ParseLayout(Slash, v)
+		fmt.Println(p.Tipo(), p, p.Format(Long))
+	}
+	// Output:
+	// Mensual 202003 marzo 2020
+	// Diario 20200315 15 marzo 2020
+	// Trimestral 2020T1 primer trimestre 2020
+}
+
+// layouts son todos los layouts definidos.
+var layouts = []Layout{Compact, ISO, SlashISO, Slash, Long, Short}
+
+func TestFormatRoundTrip(t *testing.T) {
+	var ps []Periodo
+	for _, y := range []uint{MinYear, 2020, MaxYear} {
+		p, _ := NewAnual(y)
+		ps = append(ps, p)
+		for m := uint(0); m <= 12; m++ {
+			p, _ = NewMensual(y, m)
+			ps = append(ps, p)
+			for d := uint(0); d <= DaysIn(y, m); d++ {
+				p, _ = NewDiario(y, m, d)
+				ps = append(ps, p)
+			}
+		}
+		for m := uint(1); m <= 12; m++ {
+			for q := uint(1); q <= 2; q++ {
+				p, _ = NewQuincenal(y, m, q)
+				ps = append(ps, p)
+			}
+		}
+		for _, t := range []tipoPeriodo{Bimestral, Trimestral, Cuatrimestral, Semestral} {
+			for n := uint(1); n <= 12/mesesDe(t); n++ {
+				p, _ = newTestOrdinal(t, y, n)
+				ps = append(ps, p)
+			}
+		}
+	}
+	for _, l := range layouts {
+		for _, p := range ps {
+			s := p.Format(l)
+			got, err := ParseLayout(l, s)
+			if err != nil || !got.Equal(p) || got.Tipo() != p.Tipo() {
+				t.Errorf("ParseLayout(%v, %q) = %v %v, %v, want %v %v", l, s, got.Tipo(), got, err, p.Tipo(), p)
+			}
+			got, err = ParseLayoutTipo(l, p.Tipo(), s)
+			if err != nil || !got.Equal(p) {
+				t.Errorf("ParseLayoutTipo(%v, %v, %q) = %v, %v, want %v", l, p.Tipo(), s, got, err, p)
+			}
+		}
+	}
+	for _, p := range ps {
+		if p.Format(Compact) != p.String() {
+			t.Errorf("Format(Compact) = %q, want %q", p.Format(Compact), p.String())
+		}
+	}
+}
+
+func newTestOrdinal(t tipoPeriodo, y, n uint) (Periodo, error) {
+	return predeterminado.build(t, y, n, 0)
+}
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		l       Layout
+		v       string
+		want    string
+		wantErr string
+	}{
+		{Slash, "03/2020", "202003", ""},
+		{Slash, "3/2020", "202003", ""},
+		{Slash, "5/3/2020", "20200305", ""},
+		{Slash, "q2/3/2020", "2020032", ""},
+		{Slash, "s2/2020", "2020S2", ""},
+		{ISO, "2020-03", "202003", ""},
+		{ISO, "2020-3-5", "20200305", ""},
+		{ISO, "2020-C3", "2020C3", ""},
+		{SlashISO, "2020/03/15", "20200315", ""},
+		{Long, "marzo 2020", "202003", ""},
+		{Long, "  Marzo   2020 ", "202003", ""},
+		{Long, "setiembre 2020", "202009", ""},
+		{Long, "1 SEPTIEMBRE 2020", "20200901", ""},
+		{Long, "segunda quincena diciembre 2019", "2019122", ""},
+		{Long, "1er semestre 2020", "2020S1", ""},
+		{Long, "tercer bimestre 2020", "2020B3", ""},
+		{Long, "tercero cuatrimestre 2020", "2020C3", ""},
+		{Short, "set 2020", "202009", ""},
+		{Short, "ene 2021", "202101", ""},
+		{Short, "b6 2021", "2021B6", ""},
+		{Compact, "2020T4", "2020T4", ""},
+		{Slash, "2020", "2020", ""},
+		{Slash, "31/02/2020", "", "período diario 20200231 inválido: el mes 2 del año 2020 tiene 29 días"},
+		{Long, "quinto trimestre 2020", "", "período trimestral 2020T5 inválido: el trimestre 5 está fuera del rango [1,4]"},
+		{Long, "marzo de 2020", "", `formato de período Long incorrecto: "marzo de 2020"`},
+		{Short, "marzo 2020", "", `formato de período Short incorrecto: "marzo 2020"`},
+		{Compact, "202003x", "", `formato de período Compact incorrecto: "202003x"`},
+		{ISO, "20-03-2020", "", `formato de período ISO incorrecto: "20-03-2020"`},
+		{Layout(99), "2020", "", "formato de período desconocido: Layout(99)"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v %s", test.l, test.v), func(t *testing.T) {
+			got, err := ParseLayout(test.l, test.v)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Errorf("ParseLayout() error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLayout() unexpected error = %v", err)
+			}
+			if got.String() != test.want {
+				t.Errorf("ParseLayout() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseLayoutTipo(t *testing.T) {
+	if p, err := ParseLayoutTipo(Slash, Mensual, "03/2020"); err != nil || p.String() != "202003" {
+		t.Errorf("ParseLayoutTipo() = %v, %v", p, err)
+	}
+	_, err := ParseLayoutTipo(Slash, Diario, "03/2020")
+	if err == nil || err.Error() != `formato de período diario Slash incorrecto: "03/2020"` {
+		t.Errorf("ParseLayoutTipo() error = %v", err)
+	}
+	_, err = ParseLayoutTipo(Long, Semestral, "primer trimestre 2020")
+	if err == nil {
+		t.Error("ParseLayoutTipo() expected error")
+	}
+}
+
+func TestFormatZeroAndUnknown(t *testing.T) {
+	if s := (Periodo{}).Format(ISO); s != "" {
+		t.Errorf("Format() = %q, want empty", s)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Format() expected panic did not occur")
+		}
+	}()
+	_ = mustParse(t, "2020").Format(Layout(99))
+}
@@ -24,10 +24,10 @@ package periodo
 
 import "strconv"
 
-var (
-	// MinYear establece el año mínimo válido
+const (
+	// MinYear establece el año mínimo válido para DefaultValidator
 	MinYear uint = 1000
-	// MaxYear establece el año máximo válido
+	// MaxYear establece el año máximo válido para DefaultValidator
 	MaxYear uint = 9999
 )
 
@@ -48,31 +48,77 @@ const (
 	Mensual
 	// Anual es el tipo de los períodos con forma YYYY
 	Anual
+	// Quincenal es el tipo de los períodos con forma YYYYMMQ siendo Q la
+	// quincena del mes (1 o 2)
+	Quincenal
+	// Bimestral es el tipo de los períodos con forma YYYYBB siendo BB el
+	// bimestre del año (01 a 06)
+	Bimestral
+	// Trimestral es el tipo de los períodos con forma YYYYTT siendo TT el
+	// trimestre del año (01 a 04)
+	Trimestral
+	// Cuatrimestral es el tipo de los períodos con forma YYYYCC siendo CC el
+	// cuatrimestre del año (01 a 03)
+	Cuatrimestral
+	// Semestral es el tipo de los períodos con forma YYYYSS siendo SS el
+	// semestre del año (01 o 02)
+	Semestral
 )
 
-// Parse intenta extraer un período fiscal de v del tipo especificado en t.
-// En caso de éxito retorna true en match y las partes del período extraído
-// correspondientes en y, m y d.
-func Parse(t tipoPeriodo, v string) (match bool, y, m, d uint) {
-	i, err := strconv.ParseUint(v, 10, strconv.IntSize)
-	if err != nil {
-		return false, 0, 0, 0
-	}
+func (t tipoPeriodo) String() string {
 	switch t {
 	case Diario:
-		y, m, d := DecomposePeriodoDiario(uint(i))
-		return CheckPeriodoDiario(y, m, d), y, m, d
+		return "Diario"
 	case Mensual:
-		y, m := DecomposePeriodoMensual(uint(i))
-		return CheckPeriodoMensual(y, m), y, m, 0
+		return "Mensual"
 	case Anual:
-		y := uint(i)
-		return CheckPeriodoAnual(y), y, 0, 0
+		return "Anual"
+	case Quincenal:
+		return "Quincenal"
+	case Bimestral:
+		return "Bimestral"
+	case Trimestral:
+		return "Trimestral"
+	case Cuatrimestral:
+		return "Cuatrimestral"
+	case Semestral:
+		return "Semestral"
 	default:
-		// Nunca debería ocurrir porque los usuarios de la librería no pueden
-		// crear otras instancias de tipoPeriodo porque no se exporta.
-		panic(periododesconocido)
+		return "tipoPeriodo(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// Parse intenta extraer un período fiscal de v infiriendo su tipo a partir de
+// la cantidad de dígitos: 8 para Diario, 7 para Quincenal, 6 para Mensual y 4
+// para Anual.
+//
+// Como los períodos bimestrales, trimestrales, cuatrimestrales y semestrales
+// no pueden distinguirse de los mensuales por su forma numérica, se aceptan
+// con la forma YYYYXN (la retornada por Periodo.String) siendo X la letra B,
+// T, C o S respectivamente y N el número de bimestre, trimestre,
+// cuatrimestre o semestre, por ejemplo "2020T1".
+//
+// En caso de que v no tenga alguna de esas formas o de que el período no sea
+// válido retorna un error que describe el problema.
+func Parse(v string) (Periodo, error) {
+	return predeterminado.Parse(v)
+}
+
+// ParseTipo intenta extraer un período fiscal de v del tipo especificado en t.
+//
+// En caso de que v no sea un número o de que el período no sea válido
+// retorna un error que describe el problema.
+func ParseTipo(t tipoPeriodo, v string) (Periodo, error) {
+	return predeterminado.ParseTipo(t, v)
+}
+
+func digits(v string) bool {
+	for _, r := range v {
+		if r < '0' || r > '9' {
+			return false
+		}
 	}
+	return v != ""
 }
 
 // ComposePeriodoDiario permite armar un período diario desde sus componentes.
@@ -103,23 +149,13 @@ func DecomposePeriodoMensual(p uint) (y, m uint) {
 //
 // Valida que:
 //
-//   - El año (y) esté dentro del rango [MinYear, MaxYear] (que por defecto es [1000,9999])
+//   - El año (y) esté dentro del rango [MinYear, MaxYear] (ver DefaultValidator)
 //   - El mes (m) esté dentro del rango [0,12]
 //   - Que el día (d):
 //     - Si m = 0: sea igual a 0
-//     - Si m > 0: esté dentro del rango [0, ds] siendo ds el correcto según el mes y año (considerando años bisiestos)
+//     - Si m > 0: esté dentro del rango [0, ds] siendo ds el correcto según el mes y año (ver DaysIn)
 func CheckPeriodoDiario(y, m, d uint) bool {
-	if y < MinYear || y > MaxYear || m < minMonth || m > maxMonth {
-		return false
-	}
-	if m == 0 {
-		return d == 0
-	}
-	ds := days[m]
-	if m == 2 && y%4 == 0 {
-		ds = 29
-	}
-	return d <= ds
+	return predeterminado.CheckPeriodoDiario(y, m, d)
 }
 
 // CheckPeriodoDiarioCompound valida que el período compuesto sea correcto.
@@ -132,10 +168,10 @@ func CheckPeriodoDiarioCompound(v uint) bool {
 //
 // Valida que:
 //
-//   - El año (y) esté dentro del rango [MinYear, MaxYear] (que por defecto es [1000,9999])
+//   - El año (y) esté dentro del rango [MinYear, MaxYear] (ver DefaultValidator)
 //   - El mes (m) esté dentro del rango [0,12]
 func CheckPeriodoMensual(y, m uint) bool {
-	return y >= MinYear && y <= MaxYear && m >= minMonth && m <= maxMonth
+	return predeterminado.CheckPeriodoMensual(y, m)
 }
 
 // CheckPeriodoMensualCompound valida que el período compuesto sea correcto.
@@ -148,7 +184,7 @@ func CheckPeriodoMensualCompound(v uint) bool {
 //
 // Valida que:
 //
-//   - El año (y) esté dentro del rango [MinYear, MaxYear] (que por defecto es [1000,9999])
+//   - El año (y) esté dentro del rango [MinYear, MaxYear] (ver DefaultValidator)
 func CheckPeriodoAnual(y uint) bool {
-	return y >= MinYear && y <= MaxYear
+	return predeterminado.CheckPeriodoAnual(y)
 }
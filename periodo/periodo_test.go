@@ -25,41 +25,80 @@ package periodo
 import "testing"
 
 func TestParse(t *testing.T) {
-	type args struct {
-		t tipoPeriodo
-		v string
-	}
 	tests := []struct {
-		name      string
-		args      args
-		wantMatch bool
-		wantY     uint
-		wantM     uint
-		wantD     uint
+		name    string
+		v       string
+		wantT   tipoPeriodo
+		wantY   uint
+		wantM   uint
+		wantD   uint
+		wantErr string
 	}{
-		{"base diario", args{Diario, "20000101"}, true, 2000, 1, 1},
-		{"base diario ceros", args{Diario, "20000000"}, true, 2000, 0, 0},
-		{"base mensual", args{Mensual, "200001"}, true, 2000, 1, 0},
-		{"base anual", args{Anual, "2000"}, true, 2000, 0, 0},
-		{"mal diario", args{Diario, "2000"}, false, 0, 0, 0},
-		{"mal mensual", args{Mensual, "2000"}, false, 0, 0, 0},
-		{"mal mensual mes", args{Mensual, "200014"}, false, 0, 0, 0},
+		{"base diario", "20000101", Diario, 2000, 1, 1, ""},
+		{"base diario ceros", "20000000", Diario, 2000, 0, 0, ""},
+		{"base mensual", "200001", Mensual, 2000, 1, 0, ""},
+		{"base anual", "2000", Anual, 2000, 0, 0, ""},
+		{"mal diario", "20000230", 0, 0, 0, 0, "período diario 20000230 inválido: el mes 2 del año 2000 tiene 29 días"},
+		{"mal diario mes cero", "20000001", 0, 0, 0, 0, "período diario 20000001 inválido: el día 1 debe ser 0 cuando el mes es 0"},
+		{"mal mensual mes", "200014", 0, 0, 0, 0, "período mensual 200014 inválido: el mes 14 está fuera del rango [0,12]"},
+		{"mal anual", "0999", 0, 0, 0, 0, "período anual 0999 inválido: el año 999 está fuera del rango [1000,9999]"},
+		{"mal largo", "20001", 0, 0, 0, 0, `formato de período incorrecto: "20001"`},
+		{"mal entero", "blah", 0, 0, 0, 0, `formato de período incorrecto: "blah"`},
+		{"vacío", "", 0, 0, 0, 0, `formato de período incorrecto: ""`},
+		{"con signo", "+200001", 0, 0, 0, 0, `formato de período incorrecto: "+200001"`},
 	}
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			gotMatch, gotY, gotM, gotD := Parse(test.args.t, test.args.v)
-			if gotMatch != test.wantMatch {
-				t.Errorf("Parse() gotMatch = %v, want %v", gotMatch, test.wantMatch)
+			got, err := Parse(test.v)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Errorf("Parse() error = %v, want %q", err, test.wantErr)
+				}
+				if !got.IsZero() {
+					t.Errorf("Parse() = %v, want zero", got)
+				}
+				return
 			}
-			if test.wantY != 0 && gotY != test.wantY {
-				t.Errorf("Parse() gotY = %v, want %v", gotY, test.wantY)
+			if err != nil {
+				t.Fatalf("Parse() unexpected error = %v", err)
 			}
-			if test.wantM != 0 && gotM != test.wantM {
-				t.Errorf("Parse() gotM = %v, want %v", gotM, test.wantM)
+			if got.Tipo() != test.wantT || got.Year() != test.wantY || got.Month() != test.wantM || got.Day() != test.wantD {
+				t.Errorf("Parse() = %v %d %d %d, want %v %d %d %d", got.Tipo(), got.Year(), got.Month(), got.Day(),
+					test.wantT, test.wantY, test.wantM, test.wantD)
 			}
-			if test.wantD != 0 && gotD != test.wantD {
-				t.Errorf("Parse() gotD = %v, want %v", gotD, test.wantD)
+			if got.String() != test.v {
+				t.Errorf("Parse().String() = %q, want %q", got, test.v)
+			}
+		})
+	}
+}
+
+func TestParseTipo(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       tipoPeriodo
+		v       string
+		want    uint
+		wantErr bool
+	}{
+		{"base diario", Diario, "20000101", 20000101, false},
+		{"base mensual", Mensual, "200001", 200001, false},
+		{"base anual", Anual, "2000", 2000, false},
+		{"mal diario", Diario, "2000", 0, true},
+		{"mal mensual", Mensual, "2000", 0, true},
+		{"mal mensual mes", Mensual, "200014", 0, true},
+		{"mal entero", Diario, "blah", 0, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseTipo(test.t, test.v)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseTipo() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && (got.Tipo() != test.t || got.Uint() != test.want) {
+				t.Errorf("ParseTipo() = %v %v, want %v %v", got.Tipo(), got.Uint(), test.t, test.want)
 			}
 		})
 	}
@@ -67,17 +106,12 @@ func TestParse(t *testing.T) {
 		defer func() {
 			s := recover()
 			if s == nil {
-				t.Error("Parse() expected panic did not occur")
+				t.Error("ParseTipo() expected panic did not occur")
 			} else if s != periododesconocido {
-				t.Errorf("Parse() panic got %q, want %q", s, periododesconocido)
+				t.Errorf("ParseTipo() panic got %q, want %q", s, periododesconocido)
 			}
 		}()
-		Parse(tipoPeriodo(0xff), "20000101") // should panic
-	})
-	t.Run("mal entero", func(t *testing.T) {
-		if ok, _, _, _ := Parse(Diario, "blah"); ok {
-			t.Error("Parse() expected false")
-		}
+		_, _ = ParseTipo(tipoPeriodo(0xff), "20000101") // should panic
 	})
 }
 
@@ -222,6 +256,9 @@ func TestCheckPeriodoDiario(t *testing.T) {
 		{"jan has 31 days", args{2000, 1, 31}, true},
 		{"feb 2000 had 29 days", args{2000, 2, 29}, true},
 		{"feb 2001 had not 29 days", args{2001, 2, 29}, false},
+		{"feb 1900 had not 29 days", args{1900, 2, 29}, false},
+		{"feb 2100 will not have 29 days", args{2100, 2, 29}, false},
+		{"feb 2400 will have 29 days", args{2400, 2, 29}, true},
 		{"mar has 31 days", args{2000, 3, 31}, true},
 		{"apr has 30 days", args{2000, 4, 31}, false},
 		{"may has 31 days", args{2000, 5, 31}, true},
@@ -266,6 +303,9 @@ func TestCheckPeriodoDiarioCompound(t *testing.T) {
 		{"jan has 31 days", args{20000131}, true},
 		{"feb 2000 had 29 days", args{20000229}, true},
 		{"feb 2001 had not 29 days", args{20010229}, false},
+		{"feb 1900 had not 29 days", args{19000229}, false},
+		{"feb 2100 will not have 29 days", args{21000229}, false},
+		{"feb 2400 will have 29 days", args{24000229}, true},
 		{"mar has 31 days", args{20000331}, true},
 		{"apr has 30 days", args{20000431}, false},
 		{"may has 31 days", args{20000531}, true},
@@ -0,0 +1,336 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Rango es un rango cerrado de períodos [From, To] del mismo tipo y que
+// avanzan en la misma unidad (ver Periodo.Add).
+type Rango struct {
+	from Periodo
+	to   Periodo
+}
+
+// NewRango construye el rango [from, to].
+//
+// Retorna error si los períodos no son del mismo tipo y unidad o si from es
+// posterior a to.
+func NewRango(from, to Periodo) (Rango, error) {
+	if !from.compatible(to) {
+		return Rango{}, errors.Errorf("los períodos %s y %s no son del mismo tipo", from, to)
+	}
+	if from.After(to) {
+		return Rango{}, errors.Errorf("el período %s es posterior a %s", from, to)
+	}
+	return Rango{from: from, to: to}, nil
+}
+
+// ParseRango extrae un rango de v con la forma "desde-hasta" donde desde y
+// hasta son períodos en alguna de las formas aceptadas por Parse, por
+// ejemplo "201901-202012".
+//
+// También acepta un único período, en cuyo caso retorna el rango que sólo
+// lo contiene a él.
+func ParseRango(v string) (Rango, error) {
+	parts := strings.Split(v, "-")
+	if len(parts) > 2 {
+		return Rango{}, errors.Errorf("formato de rango incorrecto: %q", v)
+	}
+	from, err := Parse(parts[0])
+	if err != nil {
+		return Rango{}, err
+	}
+	to := from
+	if len(parts) == 2 {
+		to, err = Parse(parts[1])
+		if err != nil {
+			return Rango{}, err
+		}
+	}
+	return NewRango(from, to)
+}
+
+// compatible informa si p y o son del mismo tipo y avanzan en la misma unidad.
+func (p Periodo) compatible(o Periodo) bool {
+	return p.tipo == o.tipo && p.unidad() == o.unidad()
+}
+
+// From retorna el primer período del rango.
+func (r Rango) From() Periodo {
+	return r.from
+}
+
+// To retorna el último período del rango.
+func (r Rango) To() Periodo {
+	return r.to
+}
+
+// String retorna la representación del rango con la forma "desde-hasta".
+func (r Rango) String() string {
+	return r.from.String() + "-" + r.to.String()
+}
+
+// Len retorna la cantidad de períodos del rango o 0 si es el valor cero.
+func (r Rango) Len() int {
+	if r.from.IsZero() {
+		return 0
+	}
+	n, err := r.to.Sub(r.from)
+	if err != nil {
+		return 0
+	}
+	return n + 1
+}
+
+// Periodos retorna todos los períodos del rango en orden.
+//
+// Si algún período no es válido según el validador predeterminado, como
+// puede ocurrir con rangos construidos con un Validator con otro rango de
+// años, retorna sólo los anteriores; para detectarlo debe usarse Iter.
+func (r Rango) Periodos() []Periodo {
+	ps := make([]Periodo, 0, r.Len())
+	for it := r.Iter(); it.Next(); {
+		ps = append(ps, it.Periodo())
+	}
+	return ps
+}
+
+// Contains informa si p pertenece al rango.
+func (r Rango) Contains(p Periodo) bool {
+	return r.from.compatible(p) && !p.Before(r.from) && !p.After(r.to)
+}
+
+// Overlaps informa si r y o tienen algún período en común.
+func (r Rango) Overlaps(o Rango) bool {
+	return r.from.compatible(o.from) && !r.to.Before(o.from) && !o.to.Before(r.from)
+}
+
+// Intersect retorna el rango de los períodos comunes a r y o, informando en
+// ok si existe.
+func (r Rango) Intersect(o Rango) (i Rango, ok bool) {
+	if !r.Overlaps(o) {
+		return Rango{}, false
+	}
+	return Rango{from: latest(r.from, o.from), to: earliest(r.to, o.to)}, true
+}
+
+// Union retorna el rango que contiene a los períodos de r y o, informando en
+// ok si existe, que es cuando los rangos se superponen o son contiguos.
+func (r Rango) Union(o Rango) (u Rango, ok bool) {
+	if !r.Overlaps(o) && !r.adjacent(o) && !o.adjacent(r) {
+		return Rango{}, false
+	}
+	return Rango{from: earliest(r.from, o.from), to: latest(r.to, o.to)}, true
+}
+
+// adjacent informa si o comienza en el período siguiente al último de r.
+func (r Rango) adjacent(o Rango) bool {
+	if !r.from.compatible(o.from) {
+		return false
+	}
+	n, err := o.from.Sub(r.to)
+	return err == nil && n == 1
+}
+
+// SplitByYear divide el rango en rangos que no abarcan más de un año.
+//
+// Retorna error si el primer período de alguno de los rangos resultantes no
+// es válido según el validador predeterminado (ver Periodo.Add).
+func (r Rango) SplitByYear() ([]Rango, error) {
+	var rs []Rango
+	from := r.from
+	for from.y < r.to.y {
+		to := from.lastOfYear()
+		rs = append(rs, Rango{from: from, to: to})
+		var err error
+		if from, err = to.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return append(rs, Rango{from: from, to: r.to}), nil
+}
+
+// lastOfYear retorna el último período del año de p del mismo tipo y unidad.
+func (p Periodo) lastOfYear() Periodo {
+	switch p.unidad() {
+	case años:
+		return p
+	case meses:
+		if p.tipo == Diario {
+			return Periodo{tipo: Diario, y: p.y, m: maxMonth}
+		}
+	}
+	return at(p.tipo, dia{p.y, maxMonth, DaysIn(p.y, maxMonth)})
+}
+
+// Iter retorna un Iterator sobre los períodos del rango.
+func (r Rango) Iter() *Iterator {
+	return &Iterator{rango: r}
+}
+
+// Iterator itera sobre los períodos de un rango en orden.
+//
+// Su uso es similar al de bufio.Scanner:
+//
+//	it := r.Iter()
+//	for it.Next() {
+//		p := it.Periodo()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	rango   Rango
+	cur     Periodo
+	started bool
+	done    bool
+	err     error
+}
+
+// Next avanza al siguiente período del rango retornando false cuando no
+// quedan más o cuando el siguiente período no es válido según el validador
+// predeterminado (ver Periodo.Add), en cuyo caso Err retorna el error.
+func (it *Iterator) Next() bool {
+	switch {
+	case it.done || it.rango.from.IsZero():
+		it.done = true
+	case !it.started:
+		it.started = true
+		it.cur = it.rango.from
+	case it.cur == it.rango.to:
+		it.done = true
+	default:
+		next, err := it.cur.Next()
+		if err != nil {
+			it.err = err
+			it.done = true
+		}
+		it.cur = next
+	}
+	if it.done {
+		it.cur = Periodo{}
+	}
+	return !it.done
+}
+
+// Periodo retorna el período actual.
+func (it *Iterator) Periodo() Periodo {
+	return it.cur
+}
+
+// Err retorna el error que detuvo la iteración o nil si se recorrió todo el
+// rango.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Merge retorna los rangos resultantes de unir todos los rangos de rs que se
+// superponen o son contiguos, ordenados.
+//
+// Retorna error si los rangos no son todos del mismo tipo.
+func Merge(rs []Rango) ([]Rango, error) {
+	if len(rs) == 0 {
+		return nil, nil
+	}
+	sorted := make([]Rango, len(rs))
+	copy(sorted, rs)
+	for _, r := range sorted[1:] {
+		if !r.from.compatible(sorted[0].from) {
+			return nil, errors.Errorf("los rangos %s y %s no son del mismo tipo", sorted[0], r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].from.Before(sorted[j].from) })
+	merged := []Rango{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if u, ok := last.Union(r); ok {
+			*last = u
+		} else {
+			merged = append(merged, r)
+		}
+	}
+	return merged, nil
+}
+
+// Gaps retorna los rangos de períodos de within que no están cubiertos por
+// ninguno de los rangos de rs, ordenados.
+//
+// Por ejemplo, permite detectar los períodos faltantes en un conjunto de
+// declaraciones presentadas.
+//
+// Retorna error si los rangos no son todos del mismo tipo o si algún límite
+// de los rangos resultantes no es válido según el validador predeterminado
+// (ver Periodo.Add).
+func Gaps(within Rango, rs []Rango) ([]Rango, error) {
+	merged, err := Merge(append([]Rango{}, rs...))
+	if err != nil {
+		return nil, err
+	}
+	if len(merged) > 0 && !within.from.compatible(merged[0].from) {
+		return nil, errors.Errorf("los rangos %s y %s no son del mismo tipo", within, merged[0])
+	}
+	var gaps []Rango
+	from := within.from
+	for _, r := range merged {
+		if r.to.Before(from) {
+			continue
+		}
+		if r.from.After(within.to) {
+			break
+		}
+		if r.from.After(from) {
+			to, err := r.from.Prev()
+			if err != nil {
+				return nil, err
+			}
+			gaps = append(gaps, Rango{from: from, to: to})
+		}
+		if !r.to.Before(within.to) {
+			return gaps, nil
+		}
+		if from, err = r.to.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return append(gaps, Rango{from: from, to: within.to}), nil
+}
+
+func earliest(a, b Periodo) Periodo {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func latest(a, b Periodo) Periodo {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,287 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleGaps() {
+	within, _ := ParseRango("201901-202012")
+	var presentadas []Rango
+	for _, v := range []string{"201901-201906", "201905-201910", "202001", "202003-202012"} {
+		r, _ := ParseRango(v)
+		presentadas = append(presentadas, r)
+	}
+	gaps, _ := Gaps(within, presentadas)
+	fmt.Println(gaps)
+	// Output: [201911-201912 202002-202002]
+}
+
+func ExampleRango_Iter() {
+	r, _ := ParseRango("201911-202002")
+	for it := r.Iter(); it.Next(); {
+		fmt.Println(it.Periodo())
+	}
+	// Output:
+	// 201911
+	// 201912
+	// 202001
+	// 202002
+}
+
+func mustParseRango(t *testing.T, v string) Rango {
+	t.Helper()
+	r, err := ParseRango(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func rangos(rs []Rango) string {
+	ss := make([]string, len(rs))
+	for i, r := range rs {
+		ss[i] = r.String()
+	}
+	return strings.Join(ss, " ")
+}
+
+func TestParseRango(t *testing.T) {
+	tests := []struct {
+		v       string
+		want    string
+		wantErr bool
+	}{
+		{"201901-202012", "201901-202012", false},
+		{"202003", "202003-202003", false},
+		{"20200101-20200131", "20200101-20200131", false},
+		{"2019-2020", "2019-2020", false},
+		{"202012-201901", "", true},
+		{"201901-2020", "", true},
+		{"20200100-20200131", "", true},
+		{"201901-202012-202101", "", true},
+		{"201913-202012", "", true},
+		{"", "", true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.v, func(t *testing.T) {
+			got, err := ParseRango(test.v)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseRango() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && got.String() != test.want {
+				t.Errorf("ParseRango() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRangoIter(t *testing.T) {
+	r := mustParseRango(t, "20200227-20200302")
+	if got := fmt.Sprint(r.Periodos()); got != "[20200227 20200228 20200229 20200301 20200302]" {
+		t.Errorf("Periodos() = %v", got)
+	}
+	if r.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", r.Len())
+	}
+	it := r.Iter()
+	for it.Next() {
+	}
+	if it.Next() || !it.Periodo().IsZero() {
+		t.Error("exhausted iterator must keep returning false")
+	}
+	if (Rango{}).Iter().Next() {
+		t.Error("zero Rango must have no periods")
+	}
+	if n, ps := (Rango{}).Len(), (Rango{}).Periodos(); n != 0 || len(ps) != 0 || cap(ps) != 0 {
+		t.Errorf("zero Rango Len() = %d, Periodos() = %v (cap %d), want none", n, ps, cap(ps))
+	}
+	r = mustParseRango(t, "99991230-99991231")
+	if r.Len() != 2 || len(r.Periodos()) != 2 {
+		t.Errorf("Periodos() = %v", r.Periodos())
+	}
+}
+
+func TestRangoContains(t *testing.T) {
+	r := mustParseRango(t, "201901-202012")
+	for _, test := range []struct {
+		p    string
+		want bool
+	}{
+		{"201901", true},
+		{"202012", true},
+		{"202006", true},
+		{"201812", false},
+		{"202101", false},
+		{"2020", false},
+		{"20200101", false},
+		{"202000", false},
+	} {
+		if got := r.Contains(mustParse(t, test.p)); got != test.want {
+			t.Errorf("Contains(%s) = %v, want %v", test.p, got, test.want)
+		}
+	}
+}
+
+func TestRangoSetOperations(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		overlaps  bool
+		intersect string
+		union     string
+	}{
+		{"201901-201912", "201906-202006", true, "201906-201912", "201901-202006"},
+		{"201906-202006", "201901-201912", true, "201906-201912", "201901-202006"},
+		{"201901-202012", "202003-202005", true, "202003-202005", "201901-202012"},
+		{"201901-201912", "201912-202012", true, "201912-201912", "201901-202012"},
+		{"201901-201912", "202001-202012", false, "", "201901-202012"},
+		{"202001-202012", "201901-201912", false, "", "201901-202012"},
+		{"201901-201911", "202001-202012", false, "", ""},
+		{"201901-201912", "2019-2020", false, "", ""},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.a+" "+test.b, func(t *testing.T) {
+			a, b := mustParseRango(t, test.a), mustParseRango(t, test.b)
+			if got := a.Overlaps(b); got != test.overlaps {
+				t.Errorf("Overlaps() = %v, want %v", got, test.overlaps)
+			}
+			i, ok := a.Intersect(b)
+			if ok != (test.intersect != "") || (ok && i.String() != test.intersect) {
+				t.Errorf("Intersect() = %v, %v, want %v", i, ok, test.intersect)
+			}
+			u, ok := a.Union(b)
+			if ok != (test.union != "") || (ok && u.String() != test.union) {
+				t.Errorf("Union() = %v, %v, want %v", u, ok, test.union)
+			}
+		})
+	}
+}
+
+func TestRangoSplitByYear(t *testing.T) {
+	tests := []struct {
+		r    string
+		want string
+	}{
+		{"201906-202103", "201906-201912 202001-202012 202101-202103"},
+		{"202001-202012", "202001-202012"},
+		{"20191230-20200102", "20191230-20191231 20200101-20200102"},
+		{"20191100-20200200", "20191100-20191200 20200100-20200200"},
+		{"2018-2020", "2018-2018 2019-2019 2020-2020"},
+		{"201800-202000", "201800-201800 201900-201900 202000-202000"},
+	}
+	for _, test := range tests {
+		rs, err := mustParseRango(t, test.r).SplitByYear()
+		if got := rangos(rs); err != nil || got != test.want {
+			t.Errorf("SplitByYear(%s) = %v, %v, want %v", test.r, got, err, test.want)
+		}
+	}
+}
+
+func TestRangoValidator(t *testing.T) {
+	v := DefaultValidator()
+	v.MinYear = 500
+	from, _ := v.NewMensual(500, 1)
+	to, _ := v.NewMensual(500, 3)
+	r, err := NewRango(from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := r.Iter()
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != 1 || it.Err() == nil {
+		t.Errorf("Iter() = %d períodos, Err() = %v, want 1 and error", n, it.Err())
+	}
+	if ps := r.Periodos(); len(ps) != 1 {
+		t.Errorf("Periodos() = %v", ps)
+	}
+	to, _ = v.NewMensual(501, 3)
+	if r, err = NewRango(from, to); err != nil {
+		t.Fatal(err)
+	}
+	if rs, err := r.SplitByYear(); err == nil {
+		t.Errorf("SplitByYear() = %v, want error", rs)
+	}
+	if gaps, err := Gaps(r, []Rango{{from: to, to: to}}); err == nil {
+		t.Errorf("Gaps() = %v, want error", gaps)
+	}
+	if it := mustParseRango(t, "202001-202003").Iter(); it.Err() != nil {
+		t.Errorf("Err() = %v", it.Err())
+	}
+}
+
+func TestMergeGaps(t *testing.T) {
+	tests := []struct {
+		within string
+		rs     []string
+		merged string
+		gaps   string
+	}{
+		{"201901-201912", nil, "", "201901-201912"},
+		{"201901-201912", []string{"201901-201912"}, "201901-201912", ""},
+		{"201901-201912", []string{"201801-202012"}, "201801-202012", ""},
+		{"201901-201912", []string{"201903-201904", "201901", "201902"}, "201901-201904", "201905-201912"},
+		{"201901-201912", []string{"201906", "201903"}, "201903-201903 201906-201906", "201901-201902 201904-201905 201907-201912"},
+		{"201901-201912", []string{"201801-201806", "202001-202006"}, "201801-201806 202001-202006", "201901-201912"},
+		{"201901-201912", []string{"201812-201902", "201911-202001"}, "201812-201902 201911-202001", "201903-201910"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.within+" "+strings.Join(test.rs, " "), func(t *testing.T) {
+			var rs []Rango
+			for _, r := range test.rs {
+				rs = append(rs, mustParseRango(t, r))
+			}
+			merged, err := Merge(rs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rangos(merged); got != test.merged {
+				t.Errorf("Merge() = %v, want %v", got, test.merged)
+			}
+			gaps, err := Gaps(mustParseRango(t, test.within), rs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rangos(gaps); got != test.gaps {
+				t.Errorf("Gaps() = %v, want %v", got, test.gaps)
+			}
+		})
+	}
+	t.Run("distintos tipos", func(t *testing.T) {
+		rs := []Rango{mustParseRango(t, "201901-201912"), mustParseRango(t, "2019-2020")}
+		if _, err := Merge(rs); err == nil {
+			t.Error("Merge() expected error")
+		}
+		if _, err := Gaps(mustParseRango(t, "2019-2020"), rs[:1]); err == nil {
+			t.Error("Gaps() expected error")
+		}
+	})
+}
@@ -0,0 +1,199 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+// mesesDe retorna la cantidad de meses que abarcan los períodos bimestrales,
+// trimestrales, cuatrimestrales y semestrales o 0 para los demás tipos.
+func mesesDe(t tipoPeriodo) uint {
+	switch t {
+	case Bimestral:
+		return 2
+	case Trimestral:
+		return 3
+	case Cuatrimestral:
+		return 4
+	case Semestral:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// letra retorna la letra que identifica a los períodos bimestrales,
+// trimestrales, cuatrimestrales y semestrales en su forma textual (ver
+// Parse) o 0 para los demás tipos.
+func letra(t tipoPeriodo) byte {
+	switch t {
+	case Bimestral:
+		return 'B'
+	case Trimestral:
+		return 'T'
+	case Cuatrimestral:
+		return 'C'
+	case Semestral:
+		return 'S'
+	default:
+		return 0
+	}
+}
+
+// ComposePeriodoQuincenal permite armar un período quincenal desde sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoQuincenal.
+func ComposePeriodoQuincenal(y, m, q uint) uint {
+	return y*1000 + m*10 + q
+}
+
+// DecomposePeriodoQuincenal permite desarmar un período quincenal a sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoQuincenalCompound.
+func DecomposePeriodoQuincenal(p uint) (y, m, q uint) {
+	return p / 1000, p / 10 % 100, p % 10
+}
+
+// CheckPeriodoQuincenal valida que los componentes conformen un período quincenal correcto.
+//
+// Valida que:
+//
+//   - El año (y) esté dentro del rango [MinYear, MaxYear] (ver DefaultValidator)
+//   - El mes (m) esté dentro del rango [1,12]
+//   - La quincena (q) esté dentro del rango [1,2]
+func CheckPeriodoQuincenal(y, m, q uint) bool {
+	return predeterminado.CheckPeriodoQuincenal(y, m, q)
+}
+
+// CheckPeriodoQuincenalCompound valida que el período compuesto sea correcto.
+// Descompone usando DecomposePeriodoQuincenal y delega las validaciones a CheckPeriodoQuincenal.
+func CheckPeriodoQuincenalCompound(v uint) bool {
+	return CheckPeriodoQuincenal(DecomposePeriodoQuincenal(v))
+}
+
+// ComposePeriodoBimestral permite armar un período bimestral desde sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoBimestral.
+func ComposePeriodoBimestral(y, b uint) uint {
+	return y*100 + b
+}
+
+// DecomposePeriodoBimestral permite desarmar un período bimestral a sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoBimestralCompound.
+func DecomposePeriodoBimestral(p uint) (y, b uint) {
+	return p / 100, p % 100
+}
+
+// CheckPeriodoBimestral valida que los componentes conformen un período bimestral correcto.
+//
+// Valida que:
+//
+//   - El año (y) esté dentro del rango [MinYear, MaxYear] (ver DefaultValidator)
+//   - El bimestre (b) esté dentro del rango [1,6]
+func CheckPeriodoBimestral(y, b uint) bool {
+	return predeterminado.CheckPeriodoBimestral(y, b)
+}
+
+// CheckPeriodoBimestralCompound valida que el período compuesto sea correcto.
+// Descompone usando DecomposePeriodoBimestral y delega las validaciones a CheckPeriodoBimestral.
+func CheckPeriodoBimestralCompound(v uint) bool {
+	return CheckPeriodoBimestral(DecomposePeriodoBimestral(v))
+}
+
+// ComposePeriodoTrimestral permite armar un período trimestral desde sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoTrimestral.
+func ComposePeriodoTrimestral(y, t uint) uint {
+	return y*100 + t
+}
+
+// DecomposePeriodoTrimestral permite desarmar un período trimestral a sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoTrimestralCompound.
+func DecomposePeriodoTrimestral(p uint) (y, t uint) {
+	return p / 100, p % 100
+}
+
+// CheckPeriodoTrimestral valida que los componentes conformen un período trimestral correcto.
+//
+// Valida que:
+//
+//   - El año (y) esté dentro del rango [MinYear, MaxYear] (ver DefaultValidator)
+//   - El trimestre (t) esté dentro del rango [1,4]
+func CheckPeriodoTrimestral(y, t uint) bool {
+	return predeterminado.CheckPeriodoTrimestral(y, t)
+}
+
+// CheckPeriodoTrimestralCompound valida que el período compuesto sea correcto.
+// Descompone usando DecomposePeriodoTrimestral y delega las validaciones a CheckPeriodoTrimestral.
+func CheckPeriodoTrimestralCompound(v uint) bool {
+	return CheckPeriodoTrimestral(DecomposePeriodoTrimestral(v))
+}
+
+// ComposePeriodoCuatrimestral permite armar un período cuatrimestral desde sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoCuatrimestral.
+func ComposePeriodoCuatrimestral(y, c uint) uint {
+	return y*100 + c
+}
+
+// DecomposePeriodoCuatrimestral permite desarmar un período cuatrimestral a sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoCuatrimestralCompound.
+func DecomposePeriodoCuatrimestral(p uint) (y, c uint) {
+	return p / 100, p % 100
+}
+
+// CheckPeriodoCuatrimestral valida que los componentes conformen un período cuatrimestral correcto.
+//
+// Valida que:
+//
+//   - El año (y) esté dentro del rango [MinYear, MaxYear] (ver DefaultValidator)
+//   - El cuatrimestre (c) esté dentro del rango [1,3]
+func CheckPeriodoCuatrimestral(y, c uint) bool {
+	return predeterminado.CheckPeriodoCuatrimestral(y, c)
+}
+
+// CheckPeriodoCuatrimestralCompound valida que el período compuesto sea correcto.
+// Descompone usando DecomposePeriodoCuatrimestral y delega las validaciones a CheckPeriodoCuatrimestral.
+func CheckPeriodoCuatrimestralCompound(v uint) bool {
+	return CheckPeriodoCuatrimestral(DecomposePeriodoCuatrimestral(v))
+}
+
+// ComposePeriodoSemestral permite armar un período semestral desde sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoSemestral.
+func ComposePeriodoSemestral(y, s uint) uint {
+	return y*100 + s
+}
+
+// DecomposePeriodoSemestral permite desarmar un período semestral a sus componentes.
+// No realiza ningún tipo de validación, para eso usar CheckPeriodoSemestralCompound.
+func DecomposePeriodoSemestral(p uint) (y, s uint) {
+	return p / 100, p % 100
+}
+
+// CheckPeriodoSemestral valida que los componentes conformen un período semestral correcto.
+//
+// Valida que:
+//
+//   - El año (y) esté dentro del rango [MinYear, MaxYear] (ver DefaultValidator)
+//   - El semestre (s) esté dentro del rango [1,2]
+func CheckPeriodoSemestral(y, s uint) bool {
+	return predeterminado.CheckPeriodoSemestral(y, s)
+}
+
+// CheckPeriodoSemestralCompound valida que el período compuesto sea correcto.
+// Descompone usando DecomposePeriodoSemestral y delega las validaciones a CheckPeriodoSemestral.
+func CheckPeriodoSemestralCompound(v uint) bool {
+	return CheckPeriodoSemestral(DecomposePeriodoSemestral(v))
+}
@@ -0,0 +1,321 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestComposeDecomposeSubanual(t *testing.T) {
+	if got := ComposePeriodoQuincenal(2020, 3, 2); got != 2020032 {
+		t.Errorf("ComposePeriodoQuincenal() = %d", got)
+	}
+	if y, m, q := DecomposePeriodoQuincenal(2020032); y != 2020 || m != 3 || q != 2 {
+		t.Errorf("DecomposePeriodoQuincenal() = %d %d %d", y, m, q)
+	}
+	for _, c := range []struct {
+		compose   func(uint, uint) uint
+		decompose func(uint) (uint, uint)
+	}{
+		{ComposePeriodoBimestral, DecomposePeriodoBimestral},
+		{ComposePeriodoTrimestral, DecomposePeriodoTrimestral},
+		{ComposePeriodoCuatrimestral, DecomposePeriodoCuatrimestral},
+		{ComposePeriodoSemestral, DecomposePeriodoSemestral},
+	} {
+		if got := c.compose(2020, 2); got != 202002 {
+			t.Errorf("Compose() = %d", got)
+		}
+		if y, n := c.decompose(202002); y != 2020 || n != 2 {
+			t.Errorf("Decompose() = %d %d", y, n)
+		}
+	}
+}
+
+func TestCheckSubanual(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(uint) bool
+		v     uint
+		want  bool
+	}{
+		{"quincenal", CheckPeriodoQuincenalCompound, 2020011, true},
+		{"quincenal 2", CheckPeriodoQuincenalCompound, 2020122, true},
+		{"quincenal mes 0", CheckPeriodoQuincenalCompound, 2020001, false},
+		{"quincenal mes 13", CheckPeriodoQuincenalCompound, 2020131, false},
+		{"quincenal 0", CheckPeriodoQuincenalCompound, 2020010, false},
+		{"quincenal 3", CheckPeriodoQuincenalCompound, 2020013, false},
+		{"quincenal año", CheckPeriodoQuincenalCompound, 999011, false},
+		{"bimestral", CheckPeriodoBimestralCompound, 202006, true},
+		{"bimestral 0", CheckPeriodoBimestralCompound, 202000, false},
+		{"bimestral 7", CheckPeriodoBimestralCompound, 202007, false},
+		{"trimestral", CheckPeriodoTrimestralCompound, 202004, true},
+		{"trimestral 5", CheckPeriodoTrimestralCompound, 202005, false},
+		{"cuatrimestral", CheckPeriodoCuatrimestralCompound, 202003, true},
+		{"cuatrimestral 4", CheckPeriodoCuatrimestralCompound, 202004, false},
+		{"semestral", CheckPeriodoSemestralCompound, 202002, true},
+		{"semestral 3", CheckPeriodoSemestralCompound, 202003, false},
+		{"semestral año", CheckPeriodoSemestralCompound, 1000001, false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.check(test.v); got != test.want {
+				t.Errorf("Check(%d) = %v, want %v", test.v, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseSubanual(t *testing.T) {
+	tests := []struct {
+		v       string
+		tipo    tipoPeriodo
+		month   uint
+		day     uint
+		ordinal uint
+		number  uint
+		wantErr string
+	}{
+		{"2020031", Quincenal, 3, 1, 1, 2020031, ""},
+		{"2020032", Quincenal, 3, 16, 2, 2020032, ""},
+		{"2020B3", Bimestral, 5, 0, 3, 202003, ""},
+		{"2020T2", Trimestral, 4, 0, 2, 202002, ""},
+		{"2020C3", Cuatrimestral, 9, 0, 3, 202003, ""},
+		{"2020S2", Semestral, 7, 0, 2, 202002, ""},
+		{"2020033", 0, 0, 0, 0, 0, "período quincenal 2020033 inválido: la quincena 3 está fuera del rango [1,2]"},
+		{"2020131", 0, 0, 0, 0, 0, "período quincenal 2020131 inválido: el mes 13 está fuera del rango [1,12]"},
+		{"2020B7", 0, 0, 0, 0, 0, "período bimestral 2020B7 inválido: el bimestre 7 está fuera del rango [1,6]"},
+		{"2020T0", 0, 0, 0, 0, 0, "período trimestral 2020T0 inválido: el trimestre 0 está fuera del rango [1,4]"},
+		{"2020C4", 0, 0, 0, 0, 0, "período cuatrimestral 2020C4 inválido: el cuatrimestre 4 está fuera del rango [1,3]"},
+		{"0999S1", 0, 0, 0, 0, 0, "período semestral 0999S1 inválido: el año 999 está fuera del rango [1000,9999]"},
+		{"2020X1", 0, 0, 0, 0, 0, `formato de período incorrecto: "2020X1"`},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.v, func(t *testing.T) {
+			p, err := Parse(test.v)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Errorf("Parse() error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Tipo() != test.tipo || p.Month() != test.month || p.Day() != test.day || p.Ordinal() != test.ordinal {
+				t.Errorf("Parse() = %v %d %d %d", p.Tipo(), p.Month(), p.Day(), p.Ordinal())
+			}
+			if p.Uint() != test.number || p.String() != test.v {
+				t.Errorf("Uint() = %d, String() = %q", p.Uint(), p)
+			}
+			q, err := ParseTipo(test.tipo, fmt.Sprint(test.number))
+			if err != nil || q != p {
+				t.Errorf("ParseTipo() = %v, %v, want %v", q, err, p)
+			}
+		})
+	}
+}
+
+func TestAddSubanual(t *testing.T) {
+	tests := []struct {
+		p    string
+		n    int
+		want string
+	}{
+		{"2020031", 1, "2020032"},
+		{"2020122", 1, "2021011"},
+		{"2020011", -1, "2019122"},
+		{"2020011", 24, "2021011"},
+		{"2020B6", 1, "2021B1"},
+		{"2020B1", -7, "2018B6"},
+		{"2020T4", 1, "2021T1"},
+		{"2020T1", 6, "2021T3"},
+		{"2020C3", 1, "2021C1"},
+		{"2020S1", -1, "2019S2"},
+		{"2020S2", 4, "2022S2"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%s%+d", test.p, test.n), func(t *testing.T) {
+			p := mustParse(t, test.p)
+			got, err := p.Add(test.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != test.want {
+				t.Errorf("Add() = %v, want %v", got, test.want)
+			}
+			if n, err := got.Sub(p); err != nil || n != test.n {
+				t.Errorf("Sub() = %d, %v, want %d", n, err, test.n)
+			}
+		})
+	}
+	if _, err := mustParse(t, "9999S2").Next(); err == nil {
+		t.Error("Next() expected error")
+	}
+	if _, err := mustParse(t, "2020T1").Sub(mustParse(t, "202001")); err == nil {
+		t.Error("Sub() expected error")
+	}
+}
+
+func ExamplePeriodo_In() {
+	d, _ := Parse("20200815")
+	s, _ := d.In(Semestral)
+	fmt.Println(s)
+	// Output: 2020S2
+}
+
+func ExamplePeriodo_Split() {
+	t, _ := Parse("2020T2")
+	ms, _ := t.Split(Mensual)
+	fmt.Println(ms)
+	// Output: [202004 202005 202006]
+}
+
+func TestIn(t *testing.T) {
+	tests := []struct {
+		p       string
+		t       tipoPeriodo
+		want    string
+		wantErr bool
+	}{
+		{"20200815", Diario, "20200815", false},
+		{"20200815", Quincenal, "2020081", false},
+		{"20200816", Quincenal, "2020082", false},
+		{"20200815", Mensual, "202008", false},
+		{"20200815", Bimestral, "2020B4", false},
+		{"20200815", Trimestral, "2020T3", false},
+		{"20200815", Cuatrimestral, "2020C2", false},
+		{"20200815", Semestral, "2020S2", false},
+		{"20200815", Anual, "2020", false},
+		{"20200800", Mensual, "202008", false},
+		{"20200800", Quincenal, "", true},
+		{"20200000", Anual, "2020", false},
+		{"20200000", Semestral, "", true},
+		{"202000", Anual, "2020", false},
+		{"2020082", Mensual, "202008", false},
+		{"2020082", Diario, "", true},
+		{"202012", Trimestral, "2020T4", false},
+		{"2020B2", Cuatrimestral, "2020C1", false},
+		{"2020B2", Trimestral, "", true},
+		{"2020T2", Semestral, "2020S1", false},
+		{"2020C2", Semestral, "", true},
+		{"2020S2", Anual, "2020", false},
+		{"2020", Semestral, "", true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.p+" "+test.t.String(), func(t *testing.T) {
+			got, err := mustParse(t, test.p).In(test.t)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("In() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && got.String() != test.want {
+				t.Errorf("In() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		p       string
+		t       tipoPeriodo
+		want    string
+		wantErr bool
+	}{
+		{"2020T2", Mensual, "[202004 202005 202006]", false},
+		{"2020S1", Trimestral, "[2020T1 2020T2]", false},
+		{"2020S1", Bimestral, "[2020B1 2020B2 2020B3]", false},
+		{"2020C2", Bimestral, "[2020B3 2020B4]", false},
+		{"2020", Cuatrimestral, "[2020C1 2020C2 2020C3]", false},
+		{"202002", Quincenal, "[2020021 2020022]", false},
+		{"2020022", Diario, "[20200216 20200217 20200218 20200219 20200220 20200221 20200222 20200223 20200224 20200225 20200226 20200227 20200228 20200229]", false},
+		{"20200200", Quincenal, "[2020021 2020022]", false},
+		{"202000", Semestral, "[2020S1 2020S2]", false},
+		{"2020T2", Trimestral, "[2020T2]", false},
+		{"2020T2", Bimestral, "", true},
+		{"2020B2", Trimestral, "", true},
+		{"2020C1", Semestral, "", true},
+		{"202001", Anual, "", true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.p+" "+test.t.String(), func(t *testing.T) {
+			got, err := mustParse(t, test.p).Split(test.t)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Split() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && fmt.Sprint(got) != test.want {
+				t.Errorf("Split() = %v, want %v", got, test.want)
+			}
+		})
+	}
+	t.Run("días del año", func(t *testing.T) {
+		for _, y := range []string{"2019", "2020"} {
+			ds, err := mustParse(t, y).Split(Diario)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := 365
+			if IsLeap(mustParse(t, y).Year()) {
+				want = 366
+			}
+			if len(ds) != want {
+				t.Errorf("len(Split()) = %d, want %d", len(ds), want)
+			}
+		}
+	})
+}
+
+func TestSplitValidator(t *testing.T) {
+	v := DefaultValidator()
+	v.MinYear = 500
+	p, err := v.NewAnual(500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := p.Split(Mensual); err == nil {
+		t.Errorf("Split() = %v, want error", got)
+	}
+	if got, err := p.Split(Anual); err != nil || len(got) != 1 {
+		t.Errorf("Split() = %v, %v", got, err)
+	}
+}
+
+func TestRangoSubanual(t *testing.T) {
+	r := mustParseRango(t, "2019T3-2021T1")
+	rs, err := r.SplitByYear()
+	if got := rangos(rs); err != nil || got != "2019T3-2019T4 2020T1-2020T4 2021T1-2021T1" {
+		t.Errorf("SplitByYear() = %v, %v", got, err)
+	}
+	if r.Len() != 7 {
+		t.Errorf("Len() = %d, want 7", r.Len())
+	}
+	r = mustParseRango(t, "2019122-2020012")
+	if got := fmt.Sprint(r.Periodos()); got != "[2019122 2020011 2020012]" {
+		t.Errorf("Periodos() = %v", got)
+	}
+}
@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// zonaBuenosAires es el nombre de la zona horaria usada por defecto.
+const zonaBuenosAires = "America/Argentina/Buenos_Aires"
+
+var (
+	buenosAires     *time.Location
+	buenosAiresOnce sync.Once
+)
+
+// BuenosAires retorna la zona horaria America/Argentina/Buenos_Aires que se
+// usa por defecto para convertir entre períodos e instantes.
+//
+// Si la base de datos de zonas horarias no está disponible en el sistema
+// retorna una zona fija UTC-3, que es la vigente en Argentina desde 2009.
+func BuenosAires() *time.Location {
+	buenosAiresOnce.Do(func() {
+		loc, err := time.LoadLocation(zonaBuenosAires)
+		if err != nil {
+			loc = time.FixedZone("-03", -3*60*60)
+		}
+		buenosAires = loc
+	})
+	return buenosAires
+}
+
+func location(loc *time.Location) *time.Location {
+	if loc == nil {
+		return BuenosAires()
+	}
+	return loc
+}
+
+// Start retorna el primer instante del período en la zona horaria loc o en
+// la de Buenos Aires si loc es nil.
+//
+// Los períodos con día 0 comienzan el primer día del mes y los que tienen
+// mes 0 el primer día del año, es decir que se consideran el mes o el año
+// completo.
+func (p Periodo) Start(loc *time.Location) time.Time {
+	f := p.first()
+	return time.Date(int(f.y), time.Month(f.m), int(f.d), 0, 0, 0, 0, location(loc))
+}
+
+// End retorna el último instante del período en la zona horaria loc o en la
+// de Buenos Aires si loc es nil, que es el anterior en un nanosegundo al
+// comienzo del día siguiente al último día del período.
+//
+// Los períodos con día 0 terminan el último día del mes y los que tienen mes
+// 0 el último día del año, es decir que se consideran el mes o el año
+// completo.
+func (p Periodo) End(loc *time.Location) time.Time {
+	l := p.last()
+	return time.Date(int(l.y), time.Month(l.m), int(l.d)+1, 0, 0, 0, -1, location(loc))
+}
+
+// Contains informa si el instante tm pertenece al período considerando la
+// zona horaria loc o la de Buenos Aires si loc es nil.
+func (p Periodo) Contains(tm time.Time, loc *time.Location) bool {
+	return !tm.Before(p.Start(loc)) && !tm.After(p.End(loc))
+}
+
+// FromTime retorna el período de tipo t al que pertenece el instante tm en la
+// zona horaria loc o en la de Buenos Aires si loc es nil.
+//
+// Los períodos retornados nunca tienen mes o día 0: por ejemplo, el período
+// diario de un instante es el de su día y no el del mes completo. Para
+// obtener este último puede usarse FromTime con tipo Mensual.
+//
+// Retorna error si el año del instante está fuera del rango de años válidos.
+func FromTime(tm time.Time, t tipoPeriodo, loc *time.Location) (Periodo, error) {
+	tm = tm.In(location(loc))
+	if tm.Year() < 0 || !CheckPeriodoAnual(uint(tm.Year())) {
+		return Periodo{}, errors.Errorf("el año %d del instante %s está fuera del rango [%d,%d]", tm.Year(), tm.Format(time.RFC3339), MinYear, MaxYear)
+	}
+	return at(t, dia{uint(tm.Year()), uint(tm.Month()), uint(tm.Day())}), nil
+}
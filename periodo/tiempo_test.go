@@ -0,0 +1,135 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExamplePeriodo_Start() {
+	p, _ := Parse("202002")
+	fmt.Println(p.Start(nil).Format(time.RFC3339Nano))
+	fmt.Println(p.End(nil).Format(time.RFC3339Nano))
+	// Output:
+	// 2020-02-01T00:00:00-03:00
+	// 2020-02-29T23:59:59.999999999-03:00
+}
+
+func ExampleFromTime() {
+	tm := time.Date(2020, 3, 1, 1, 0, 0, 0, time.UTC) // 29/2 22hs en Argentina
+	p, _ := FromTime(tm, Diario, nil)
+	fmt.Println(p)
+	// Output: 20200229
+}
+
+func TestBuenosAires(t *testing.T) {
+	loc := BuenosAires()
+	if loc == nil || loc != BuenosAires() {
+		t.Fatal("BuenosAires() must always return the same location")
+	}
+	_, offset := time.Date(2020, 1, 1, 0, 0, 0, 0, loc).Zone()
+	if offset != -3*60*60 {
+		t.Errorf("offset = %d, want %d", offset, -3*60*60)
+	}
+}
+
+func TestStartEnd(t *testing.T) {
+	utc := time.UTC
+	tests := []struct {
+		p     string
+		start string
+		end   string
+	}{
+		{"20200315", "2020-03-15T00:00:00Z", "2020-03-15T23:59:59.999999999Z"},
+		{"20200300", "2020-03-01T00:00:00Z", "2020-03-31T23:59:59.999999999Z"},
+		{"20200000", "2020-01-01T00:00:00Z", "2020-12-31T23:59:59.999999999Z"},
+		{"2020021", "2020-02-01T00:00:00Z", "2020-02-15T23:59:59.999999999Z"},
+		{"2020022", "2020-02-16T00:00:00Z", "2020-02-29T23:59:59.999999999Z"},
+		{"202002", "2020-02-01T00:00:00Z", "2020-02-29T23:59:59.999999999Z"},
+		{"202000", "2020-01-01T00:00:00Z", "2020-12-31T23:59:59.999999999Z"},
+		{"2020B6", "2020-11-01T00:00:00Z", "2020-12-31T23:59:59.999999999Z"},
+		{"2020T1", "2020-01-01T00:00:00Z", "2020-03-31T23:59:59.999999999Z"},
+		{"2020C2", "2020-05-01T00:00:00Z", "2020-08-31T23:59:59.999999999Z"},
+		{"2020S2", "2020-07-01T00:00:00Z", "2020-12-31T23:59:59.999999999Z"},
+		{"2020", "2020-01-01T00:00:00Z", "2020-12-31T23:59:59.999999999Z"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.p, func(t *testing.T) {
+			p := mustParse(t, test.p)
+			if got := p.Start(utc).Format(time.RFC3339Nano); got != test.start {
+				t.Errorf("Start() = %v, want %v", got, test.start)
+			}
+			if got := p.End(utc).Format(time.RFC3339Nano); got != test.end {
+				t.Errorf("End() = %v, want %v", got, test.end)
+			}
+			if next, err := p.Next(); err == nil && !next.Start(utc).Equal(p.End(utc).Add(time.Nanosecond)) {
+				t.Errorf("Next().Start() = %v, want %v", next.Start(utc), p.End(utc).Add(time.Nanosecond))
+			}
+		})
+	}
+}
+
+func TestFromTime(t *testing.T) {
+	tm := time.Date(2020, 8, 15, 12, 0, 0, 0, time.UTC)
+	for _, test := range []struct {
+		t    tipoPeriodo
+		want string
+	}{
+		{Diario, "20200815"},
+		{Quincenal, "2020081"},
+		{Mensual, "202008"},
+		{Bimestral, "2020B4"},
+		{Trimestral, "2020T3"},
+		{Cuatrimestral, "2020C2"},
+		{Semestral, "2020S2"},
+		{Anual, "2020"},
+	} {
+		p, err := FromTime(tm, test.t, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.String() != test.want {
+			t.Errorf("FromTime(%v) = %v, want %v", test.t, p, test.want)
+		}
+		if !p.Contains(tm, nil) {
+			t.Errorf("%v.Contains(%v) = false", p, tm)
+		}
+	}
+	// el 1/1/2021 a las 2hs UTC sigue siendo 2020 en Argentina
+	newyear := time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC)
+	if p, _ := FromTime(newyear, Anual, nil); p.String() != "2020" {
+		t.Errorf("FromTime() = %v, want 2020", p)
+	}
+	if p, _ := FromTime(newyear, Anual, time.UTC); p.String() != "2021" {
+		t.Errorf("FromTime() = %v, want 2021", p)
+	}
+	if _, err := FromTime(time.Date(999, 12, 31, 0, 0, 0, 0, time.UTC), Diario, time.UTC); err == nil {
+		t.Error("FromTime() expected error")
+	}
+	if p := mustParse(t, "2020"); p.Contains(newyear, nil) != true || p.Contains(newyear, time.UTC) != false {
+		t.Error("Contains() must consider the location")
+	}
+}
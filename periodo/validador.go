@@ -0,0 +1,366 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FuturePolicy establece cómo trata un Validator a los períodos que todavía
+// no comenzaron o no terminaron.
+type FuturePolicy uint8
+
+const (
+	// AllowFuture acepta los períodos sin importar cuándo comienzan.
+	AllowFuture FuturePolicy = iota
+	// RejectFuture rechaza los períodos que comienzan después del instante
+	// actual pero acepta el que está en curso.
+	RejectFuture
+	// RejectOpen rechaza los períodos que no terminaron antes del instante
+	// actual, incluido el que está en curso.
+	RejectOpen
+)
+
+func (f FuturePolicy) String() string {
+	switch f {
+	case AllowFuture:
+		return "AllowFuture"
+	case RejectFuture:
+		return "RejectFuture"
+	case RejectOpen:
+		return "RejectOpen"
+	default:
+		return "FuturePolicy(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+// Validator establece las reglas con las que se validan los períodos.
+//
+// Sus métodos nunca lo modifican, por lo que un mismo Validator puede usarse
+// desde varias goroutines. Para obtener uno con reglas propias conviene
+// partir de DefaultValidator y cambiar sólo los campos necesarios:
+//
+//	v := periodo.DefaultValidator()
+//	v.MinYear = 2000
+//	v.Future = periodo.RejectOpen
+//	p, err := v.Parse("202013")
+//
+// Las funciones del paquete (Parse, NewDiario, CheckPeriodoDiario, etc.)
+// usan siempre las reglas de DefaultValidator, que no pueden modificarse.
+type Validator struct {
+	// MinYear y MaxYear establecen el rango de años válidos.
+	MinYear, MaxYear uint
+	// ZeroMonth permite el mes 0 en los períodos diarios y mensuales.
+	ZeroMonth bool
+	// ZeroDay permite el día 0 en los períodos diarios de meses distintos
+	// de 0.
+	ZeroDay bool
+	// Future establece cómo se tratan los períodos futuros.
+	Future FuturePolicy
+	// Now retorna el instante con el que se determina si un período es
+	// futuro. Si es nil se usa time.Now.
+	Now func() time.Time
+	// Location es la zona horaria en la que se determina si un período es
+	// futuro. Si es nil se usa la de Buenos Aires.
+	Location *time.Location
+}
+
+var predeterminado = Validator{
+	MinYear:   MinYear,
+	MaxYear:   MaxYear,
+	ZeroMonth: true,
+	ZeroDay:   true,
+	Future:    AllowFuture,
+}
+
+// DefaultValidator retorna una copia del Validator que usan las funciones
+// del paquete: años en el rango [MinYear, MaxYear], mes y día 0 permitidos y
+// períodos futuros aceptados.
+func DefaultValidator() Validator {
+	return predeterminado
+}
+
+// CheckPeriodoDiario valida que los componentes conformen un período diario
+// correcto según las reglas de v.
+func (v Validator) CheckPeriodoDiario(y, m, d uint) bool {
+	return v.check(Diario, y, m, d) == ""
+}
+
+// CheckPeriodoMensual valida que los componentes conformen un período
+// mensual correcto según las reglas de v.
+func (v Validator) CheckPeriodoMensual(y, m uint) bool {
+	return v.check(Mensual, y, m, 0) == ""
+}
+
+// CheckPeriodoAnual valida que el período anual sea correcto según las
+// reglas de v.
+func (v Validator) CheckPeriodoAnual(y uint) bool {
+	return v.check(Anual, y, 0, 0) == ""
+}
+
+// CheckPeriodoQuincenal valida que los componentes conformen un período
+// quincenal correcto según las reglas de v.
+func (v Validator) CheckPeriodoQuincenal(y, m, q uint) bool {
+	return v.check(Quincenal, y, m, q) == ""
+}
+
+// CheckPeriodoBimestral valida que los componentes conformen un período
+// bimestral correcto según las reglas de v.
+func (v Validator) CheckPeriodoBimestral(y, b uint) bool {
+	return v.check(Bimestral, y, b, 0) == ""
+}
+
+// CheckPeriodoTrimestral valida que los componentes conformen un período
+// trimestral correcto según las reglas de v.
+func (v Validator) CheckPeriodoTrimestral(y, t uint) bool {
+	return v.check(Trimestral, y, t, 0) == ""
+}
+
+// CheckPeriodoCuatrimestral valida que los componentes conformen un período
+// cuatrimestral correcto según las reglas de v.
+func (v Validator) CheckPeriodoCuatrimestral(y, c uint) bool {
+	return v.check(Cuatrimestral, y, c, 0) == ""
+}
+
+// CheckPeriodoSemestral valida que los componentes conformen un período
+// semestral correcto según las reglas de v.
+func (v Validator) CheckPeriodoSemestral(y, s uint) bool {
+	return v.check(Semestral, y, s, 0) == ""
+}
+
+// NewDiario construye un período diario validándolo con las reglas de v.
+func (v Validator) NewDiario(y, m, d uint) (Periodo, error) {
+	return v.build(Diario, y, m, d)
+}
+
+// NewMensual construye un período mensual validándolo con las reglas de v.
+func (v Validator) NewMensual(y, m uint) (Periodo, error) {
+	return v.build(Mensual, y, m, 0)
+}
+
+// NewAnual construye un período anual validándolo con las reglas de v.
+func (v Validator) NewAnual(y uint) (Periodo, error) {
+	return v.build(Anual, y, 0, 0)
+}
+
+// NewQuincenal construye un período quincenal validándolo con las reglas de
+// v.
+func (v Validator) NewQuincenal(y, m, q uint) (Periodo, error) {
+	return v.build(Quincenal, y, m, q)
+}
+
+// NewBimestral construye un período bimestral validándolo con las reglas de
+// v.
+func (v Validator) NewBimestral(y, b uint) (Periodo, error) {
+	return v.build(Bimestral, y, b, 0)
+}
+
+// NewTrimestral construye un período trimestral validándolo con las reglas
+// de v.
+func (v Validator) NewTrimestral(y, t uint) (Periodo, error) {
+	return v.build(Trimestral, y, t, 0)
+}
+
+// NewCuatrimestral construye un período cuatrimestral validándolo con las
+// reglas de v.
+func (v Validator) NewCuatrimestral(y, c uint) (Periodo, error) {
+	return v.build(Cuatrimestral, y, c, 0)
+}
+
+// NewSemestral construye un período semestral validándolo con las reglas de
+// v.
+func (v Validator) NewSemestral(y, s uint) (Periodo, error) {
+	return v.build(Semestral, y, s, 0)
+}
+
+// Validate valida que p sea correcto según las reglas de v. Es útil para
+// revalidar períodos obtenidos con otras reglas, por ejemplo los retornados
+// por las funciones del paquete o por Periodo.Add.
+func (v Validator) Validate(p Periodo) error {
+	_, err := v.build(p.components())
+	return err
+}
+
+// Parse es como la función Parse del paquete pero valida el período con las
+// reglas de v.
+func (v Validator) Parse(s string) (Periodo, error) {
+	if len(s) == 6 && digits(s[:4]) && digits(s[5:]) {
+		for _, t := range []tipoPeriodo{Bimestral, Trimestral, Cuatrimestral, Semestral} {
+			if s[4] == letra(t) {
+				return v.ParseTipo(t, s[:4]+"0"+s[5:])
+			}
+		}
+	}
+	if !digits(s) {
+		return Periodo{}, errors.Errorf("formato de período incorrecto: %q", s)
+	}
+	switch len(s) {
+	case 8:
+		return v.ParseTipo(Diario, s)
+	case 7:
+		return v.ParseTipo(Quincenal, s)
+	case 6:
+		return v.ParseTipo(Mensual, s)
+	case 4:
+		return v.ParseTipo(Anual, s)
+	default:
+		return Periodo{}, errors.Errorf("formato de período incorrecto: %q", s)
+	}
+}
+
+// ParseTipo es como la función ParseTipo del paquete pero valida el período
+// con las reglas de v.
+func (v Validator) ParseTipo(t tipoPeriodo, s string) (Periodo, error) {
+	i, err := strconv.ParseUint(s, 10, strconv.IntSize)
+	if err != nil {
+		return Periodo{}, errors.Errorf("formato de período %s incorrecto: %q", strings.ToLower(t.String()), s)
+	}
+	switch t {
+	case Diario:
+		return v.NewDiario(DecomposePeriodoDiario(uint(i)))
+	case Mensual:
+		return v.NewMensual(DecomposePeriodoMensual(uint(i)))
+	case Anual:
+		return v.NewAnual(uint(i))
+	case Quincenal:
+		return v.NewQuincenal(DecomposePeriodoQuincenal(uint(i)))
+	case Bimestral:
+		return v.NewBimestral(DecomposePeriodoBimestral(uint(i)))
+	case Trimestral:
+		return v.NewTrimestral(DecomposePeriodoTrimestral(uint(i)))
+	case Cuatrimestral:
+		return v.NewCuatrimestral(DecomposePeriodoCuatrimestral(uint(i)))
+	case Semestral:
+		return v.NewSemestral(DecomposePeriodoSemestral(uint(i)))
+	default:
+		// Nunca debería ocurrir porque los usuarios de la librería no pueden
+		// crear otras instancias de tipoPeriodo porque no se exporta.
+		panic(periododesconocido)
+	}
+}
+
+// build construye el período de tipo t a partir de su año y, su número n
+// (mes, quincena o número de bimestre, trimestre, cuatrimestre o semestre
+// según el tipo) y su día o quincena d, o retorna el error que describe por
+// qué no es válido según las reglas de v.
+func (v Validator) build(t tipoPeriodo, y, n, d uint) (Periodo, error) {
+	if reason := v.check(t, y, n, d); reason != "" {
+		return Periodo{}, errors.Errorf("período %s %s inválido: %s", strings.ToLower(t.String()), format(t, y, n, d), reason)
+	}
+	return compose(t, y, n, d), nil
+}
+
+// check retorna el motivo por el que el período de tipo t con componentes y,
+// n y d (ver build) no es válido según las reglas de v o "" si lo es.
+func (v Validator) check(t tipoPeriodo, y, n, d uint) string {
+	calendario := t == Diario || t == Mensual
+	switch {
+	case y < v.MinYear || y > v.MaxYear:
+		return fmt.Sprintf("el año %d está fuera del rango [%d,%d]", y, v.MinYear, v.MaxYear)
+	case t == Quincenal && (n < 1 || n > maxMonth):
+		return fmt.Sprintf("el mes %d está fuera del rango [1,%d]", n, maxMonth)
+	case t == Quincenal && (d < 1 || d > 2):
+		return fmt.Sprintf("la quincena %d está fuera del rango [1,2]", d)
+	case mesesDe(t) > 0 && (n < 1 || n > 12/mesesDe(t)):
+		return fmt.Sprintf("el %s %d está fuera del rango [1,%d]", nombre(t), n, 12/mesesDe(t))
+	case calendario && n > maxMonth:
+		return fmt.Sprintf("el mes %d está fuera del rango [%d,%d]", n, v.minMonth(), maxMonth)
+	case calendario && n == 0 && !v.ZeroMonth:
+		return "el mes 0 no está permitido"
+	case t == Diario && n == 0 && d != 0:
+		return fmt.Sprintf("el día %d debe ser 0 cuando el mes es 0", d)
+	case t == Diario && d > DaysIn(y, n):
+		return fmt.Sprintf("el mes %d del año %d tiene %d días", n, y, DaysIn(y, n))
+	case t == Diario && n != 0 && d == 0 && !v.ZeroDay:
+		return "el día 0 no está permitido"
+	}
+	if v.Future == AllowFuture {
+		return ""
+	}
+	p, loc := compose(t, y, n, d), location(v.Location)
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	switch ahora := now(); {
+	case v.Future == RejectFuture && p.Start(loc).After(ahora):
+		return "todavía no comenzó"
+	case v.Future == RejectOpen && !p.End(loc).Before(ahora):
+		return "todavía no terminó"
+	}
+	return ""
+}
+
+func (v Validator) minMonth() uint {
+	if v.ZeroMonth {
+		return minMonth
+	}
+	return 1
+}
+
+// compose construye el período de tipo t a partir de sus componentes (ver
+// build) sin validarlos.
+func compose(t tipoPeriodo, y, n, d uint) Periodo {
+	switch {
+	case t == Quincenal:
+		return Periodo{tipo: t, y: y, m: n, d: 1 + (d-1)*15}
+	case mesesDe(t) > 0:
+		return Periodo{tipo: t, y: y, m: 1 + (n-1)*mesesDe(t)}
+	default:
+		return Periodo{tipo: t, y: y, m: n, d: d}
+	}
+}
+
+// components es la inversa de compose.
+func (p Periodo) components() (t tipoPeriodo, y, n, d uint) {
+	switch {
+	case p.tipo == Quincenal:
+		return p.tipo, p.y, p.m, p.Ordinal()
+	case mesesDe(p.tipo) > 0:
+		return p.tipo, p.y, p.Ordinal(), 0
+	default:
+		return p.tipo, p.y, p.m, p.d
+	}
+}
+
+// format retorna la forma textual del período de tipo t con componentes y,
+// n y d (ver build) aún cuando no sea válido.
+func format(t tipoPeriodo, y, n, d uint) string {
+	switch {
+	case t == Diario:
+		return fmt.Sprintf("%04d%02d%02d", y, n, d)
+	case t == Mensual:
+		return fmt.Sprintf("%04d%02d", y, n)
+	case t == Anual:
+		return fmt.Sprintf("%04d", y)
+	case t == Quincenal:
+		return fmt.Sprintf("%04d%02d%d", y, n, d)
+	default:
+		return fmt.Sprintf("%04d%c%d", y, letra(t), n)
+	}
+}
@@ -0,0 +1,152 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleValidator() {
+	v := DefaultValidator()
+	v.MinYear = 2000
+	v.ZeroMonth = false
+	_, err := v.Parse("199912")
+	fmt.Println(err)
+	_, err = v.Parse("200000")
+	fmt.Println(err)
+	p, _ := Parse("199912")
+	fmt.Println(p)
+	// Output:
+	// período mensual 199912 inválido: el año 1999 está fuera del rango [2000,9999]
+	// período mensual 200000 inválido: el mes 0 no está permitido
+	// 199912
+}
+
+func TestDefaultValidator(t *testing.T) {
+	v := DefaultValidator()
+	v.MinYear = 2000
+	v.ZeroDay = false
+	if !CheckPeriodoAnual(1999) || !CheckPeriodoDiario(2000, 1, 0) {
+		t.Error("modificar una copia de DefaultValidator no debe afectar al validador del paquete")
+	}
+	if d := DefaultValidator(); d.MinYear != MinYear || d.MaxYear != MaxYear || !d.ZeroMonth || !d.ZeroDay || d.Future != AllowFuture {
+		t.Errorf("DefaultValidator() = %+v", d)
+	}
+}
+
+func TestValidator(t *testing.T) {
+	ahora := time.Date(2020, 3, 16, 12, 0, 0, 0, BuenosAires())
+	estricto := Validator{
+		MinYear:  2000,
+		MaxYear:  2030,
+		Future:   RejectFuture,
+		Now:      func() time.Time { return ahora },
+		Location: BuenosAires(),
+	}
+	cerrados := estricto
+	cerrados.ZeroMonth = true
+	cerrados.ZeroDay = true
+	cerrados.Future = RejectOpen
+	tests := []struct {
+		name    string
+		v       Validator
+		s       string
+		wantErr string
+	}{
+		{"año mínimo", estricto, "2000", ""},
+		{"año menor", estricto, "1999", "período anual 1999 inválido: el año 1999 está fuera del rango [2000,2030]"},
+		{"año mayor", estricto, "2031T1", "período trimestral 2031T1 inválido: el año 2031 está fuera del rango [2000,2030]"},
+		{"mes cero", estricto, "202000", "período mensual 202000 inválido: el mes 0 no está permitido"},
+		{"mes fuera de rango", estricto, "202013", "período mensual 202013 inválido: el mes 13 está fuera del rango [1,12]"},
+		{"diario mes cero", estricto, "20200000", "período diario 20200000 inválido: el mes 0 no está permitido"},
+		{"día cero", estricto, "20200100", "período diario 20200100 inválido: el día 0 no está permitido"},
+		{"día", estricto, "20200229", ""},
+		{"en curso", estricto, "202003", ""},
+		{"hoy", estricto, "20200316", ""},
+		{"mañana", estricto, "20200317", "período diario 20200317 inválido: todavía no comenzó"},
+		{"quincena futura", estricto, "2020041", "período quincenal 2020041 inválido: todavía no comenzó"},
+		{"semestre en curso", estricto, "2020S1", ""},
+		{"cerrado", cerrados, "202002", ""},
+		{"cerrado ceros", cerrados, "20190000", ""},
+		{"abierto", cerrados, "202003", "período mensual 202003 inválido: todavía no terminó"},
+		{"año abierto", cerrados, "2020", "período anual 2020 inválido: todavía no terminó"},
+		{"ayer", cerrados, "20200315", ""},
+		{"hoy abierto", cerrados, "20200316", "período diario 20200316 inválido: todavía no terminó"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.v.Parse(test.s)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Errorf("Parse() error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error = %v", err)
+			}
+			if got.String() != test.s {
+				t.Errorf("Parse() = %v, want %v", got, test.s)
+			}
+			if err := test.v.Validate(got); err != nil {
+				t.Errorf("Validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestValidatorValidate(t *testing.T) {
+	v := DefaultValidator()
+	v.MaxYear = 2020
+	p, err := NewMensual(2020, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Validate(p); err != nil {
+		t.Errorf("Validate(%v) unexpected error = %v", p, err)
+	}
+	p, err = p.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Validate(p); err == nil || err.Error() != "período mensual 202101 inválido: el año 2021 está fuera del rango [1000,2020]" {
+		t.Errorf("Validate(%v) error = %v", p, err)
+	}
+	if err := v.Validate(Periodo{}); err == nil {
+		t.Error("Validate(Periodo{}) expected error")
+	}
+}
+
+func TestValidatorNow(t *testing.T) {
+	v := DefaultValidator()
+	v.Future = RejectFuture
+	if _, err := v.NewAnual(uint(time.Now().Year())); err != nil {
+		t.Errorf("NewAnual() unexpected error = %v", err)
+	}
+	if _, err := v.NewAnual(uint(time.Now().Year() + 2)); err == nil {
+		t.Error("NewAnual() expected error")
+	}
+}
@@ -0,0 +1,175 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Periodo es un período fiscal válido de alguno de los tipos definidos en
+// este paquete.
+//
+// Su valor cero no es un período válido y puede detectarse con IsZero.
+type Periodo struct {
+	tipo tipoPeriodo
+	y    uint
+	m    uint
+	d    uint
+}
+
+// NewDiario construye un período diario validándolo con CheckPeriodoDiario.
+func NewDiario(y, m, d uint) (Periodo, error) {
+	return predeterminado.NewDiario(y, m, d)
+}
+
+// NewMensual construye un período mensual validándolo con CheckPeriodoMensual.
+func NewMensual(y, m uint) (Periodo, error) {
+	return predeterminado.NewMensual(y, m)
+}
+
+// NewAnual construye un período anual validándolo con CheckPeriodoAnual.
+func NewAnual(y uint) (Periodo, error) {
+	return predeterminado.NewAnual(y)
+}
+
+// NewQuincenal construye un período quincenal validándolo con
+// CheckPeriodoQuincenal.
+func NewQuincenal(y, m, q uint) (Periodo, error) {
+	return predeterminado.NewQuincenal(y, m, q)
+}
+
+// NewBimestral construye un período bimestral validándolo con
+// CheckPeriodoBimestral.
+func NewBimestral(y, b uint) (Periodo, error) {
+	return predeterminado.NewBimestral(y, b)
+}
+
+// NewTrimestral construye un período trimestral validándolo con
+// CheckPeriodoTrimestral.
+func NewTrimestral(y, t uint) (Periodo, error) {
+	return predeterminado.NewTrimestral(y, t)
+}
+
+// NewCuatrimestral construye un período cuatrimestral validándolo con
+// CheckPeriodoCuatrimestral.
+func NewCuatrimestral(y, c uint) (Periodo, error) {
+	return predeterminado.NewCuatrimestral(y, c)
+}
+
+// NewSemestral construye un período semestral validándolo con
+// CheckPeriodoSemestral.
+func NewSemestral(y, s uint) (Periodo, error) {
+	return predeterminado.NewSemestral(y, s)
+}
+
+// nombre retorna el nombre de las partes del año en que se dividen los
+// períodos bimestrales, trimestrales, cuatrimestrales y semestrales.
+func nombre(t tipoPeriodo) string {
+	return strings.TrimSuffix(strings.ToLower(t.String()), "al") + "e"
+}
+
+// Tipo retorna el tipo del período.
+func (p Periodo) Tipo() tipoPeriodo {
+	return p.tipo
+}
+
+// Year retorna el año del período.
+func (p Periodo) Year() uint {
+	return p.y
+}
+
+// Month retorna el mes del período, que es 0 en los períodos anuales.
+//
+// En los períodos bimestrales, trimestrales, cuatrimestrales y semestrales
+// retorna el primer mes que abarcan.
+func (p Periodo) Month() uint {
+	return p.m
+}
+
+// Day retorna el día del período, que es 0 en los períodos que abarcan uno o
+// más meses.
+//
+// En los períodos quincenales retorna el primer día que abarcan (1 o 16).
+func (p Periodo) Day() uint {
+	return p.d
+}
+
+// Ordinal retorna el número de quincena del mes en los períodos quincenales
+// o de bimestre, trimestre, cuatrimestre o semestre del año en los períodos
+// de esos tipos. En los demás tipos de período retorna 0.
+func (p Periodo) Ordinal() uint {
+	switch {
+	case p.tipo == Quincenal:
+		return 1 + p.d/16
+	case mesesDe(p.tipo) > 0:
+		return 1 + (p.m-1)/mesesDe(p.tipo)
+	default:
+		return 0
+	}
+}
+
+// IsZero informa si p es el valor cero de Periodo.
+func (p Periodo) IsZero() bool {
+	return p == Periodo{}
+}
+
+// Uint retorna la representación numérica del período según su tipo (ver
+// las funciones Compose de cada tipo).
+func (p Periodo) Uint() uint {
+	switch p.tipo {
+	case Diario:
+		return ComposePeriodoDiario(p.y, p.m, p.d)
+	case Mensual:
+		return ComposePeriodoMensual(p.y, p.m)
+	case Anual:
+		return p.y
+	case Quincenal:
+		return ComposePeriodoQuincenal(p.y, p.m, p.Ordinal())
+	case Bimestral, Trimestral, Cuatrimestral, Semestral:
+		return p.y*100 + p.Ordinal()
+	default:
+		panic(periododesconocido)
+	}
+}
+
+// String retorna la representación del período con la forma compacta que
+// corresponde a su tipo (YYYYMMDD, YYYYMMQ, YYYYMM o YYYY) salvo para los
+// períodos bimestrales, trimestrales, cuatrimestrales y semestrales que usan
+// la forma YYYYXN (ver Parse).
+func (p Periodo) String() string {
+	switch p.tipo {
+	case Diario:
+		return fmt.Sprintf("%04d%02d%02d", p.y, p.m, p.d)
+	case Mensual:
+		return fmt.Sprintf("%04d%02d", p.y, p.m)
+	case Anual:
+		return fmt.Sprintf("%04d", p.y)
+	case Quincenal:
+		return fmt.Sprintf("%04d%02d%d", p.y, p.m, p.Ordinal())
+	case Bimestral, Trimestral, Cuatrimestral, Semestral:
+		return fmt.Sprintf("%04d%c%d", p.y, letra(p.tipo), p.Ordinal())
+	default:
+		panic(periododesconocido)
+	}
+}
@@ -0,0 +1,112 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package periodo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleParse() {
+	p, err := Parse("202003")
+	if err != nil {
+		// manejar el error
+	}
+	fmt.Println(p.Tipo(), p.Year(), p.Month(), p)
+	_, err = Parse("20210229")
+	fmt.Println(err)
+	// Output:
+	// Mensual 2020 3 202003
+	// período diario 20210229 inválido: el mes 2 del año 2021 tiene 28 días
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		new     func() (Periodo, error)
+		want    string
+		wantErr bool
+	}{
+		{"diario", func() (Periodo, error) { return NewDiario(2020, 3, 15) }, "20200315", false},
+		{"diario mes", func() (Periodo, error) { return NewDiario(2020, 3, 0) }, "20200300", false},
+		{"diario mal", func() (Periodo, error) { return NewDiario(2020, 4, 31) }, "", true},
+		{"mensual", func() (Periodo, error) { return NewMensual(2020, 3) }, "202003", false},
+		{"mensual año", func() (Periodo, error) { return NewMensual(2020, 0) }, "202000", false},
+		{"mensual mal", func() (Periodo, error) { return NewMensual(2020, 13) }, "", true},
+		{"anual", func() (Periodo, error) { return NewAnual(2020) }, "2020", false},
+		{"anual mal", func() (Periodo, error) { return NewAnual(10000) }, "", true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.new()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				if !got.IsZero() {
+					t.Errorf("got %v, want zero", got)
+				}
+				return
+			}
+			if got.String() != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPeriodoUint(t *testing.T) {
+	d, _ := NewDiario(2020, 3, 15)
+	m, _ := NewMensual(2020, 3)
+	a, _ := NewAnual(2020)
+	for _, test := range []struct {
+		p    Periodo
+		want uint
+	}{{d, 20200315}, {m, 202003}, {a, 2020}} {
+		if got := test.p.Uint(); got != test.want {
+			t.Errorf("Uint() = %d, want %d", got, test.want)
+		}
+	}
+}
+
+func TestTipoPeriodoString(t *testing.T) {
+	for _, test := range []struct {
+		t    tipoPeriodo
+		want string
+	}{
+		{Diario, "Diario"},
+		{Mensual, "Mensual"},
+		{Anual, "Anual"},
+		{Quincenal, "Quincenal"},
+		{Bimestral, "Bimestral"},
+		{Trimestral, "Trimestral"},
+		{Cuatrimestral, "Cuatrimestral"},
+		{Semestral, "Semestral"},
+		{tipoPeriodo(0xff), "tipoPeriodo(255)"},
+	} {
+		if got := test.t.String(); got != test.want {
+			t.Errorf("String() = %q, want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package signature permite verificar la firma de los tokens de
+// autenticación emitidos por AFIP.
+//
+// Los servicios que reciben un par token y sign deben verificar que sign
+// sea una firma RSA del XML del token hecha con la clave del emisor
+// indicado en el token (su atributo src). Un Verifier busca los
+// certificados del emisor en un Store, descarta los que no estaban vigentes
+// cuando se generó el token, de modo que puedan convivir los certificados
+// anterior y nuevo durante su rotación, y verifica la firma con los
+// algoritmos permitidos por su Policy:
+//
+//	store, err := signature.NewDirStore("/etc/afip/certs")
+//	...
+//	v := signature.NewVerifier(store)
+//	t, err := v.Verify(tokenBase64, signBase64)
+//	...
+//	err = t.Validate(time.Now(), token.DefaultSkew)
+package signature
@@ -0,0 +1,71 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signature
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+// UnknownSourceError es el error retornado cuando el Store no tiene
+// certificados del emisor de un token.
+type UnknownSourceError struct {
+	Source string
+}
+
+func (e *UnknownSourceError) Error() string {
+	return fmt.Sprintf("no hay certificados del emisor %q", e.Source)
+}
+
+// ExpiredCertificateError es el error retornado cuando ninguno de los
+// certificados del emisor de un token estaba vigente al generarse el token.
+type ExpiredCertificateError struct {
+	Source string
+	Time   time.Time
+}
+
+func (e *ExpiredCertificateError) Error() string {
+	return fmt.Sprintf("no hay certificados del emisor %q vigentes al %s", e.Source, e.Time.Format(time.RFC3339))
+}
+
+// PolicyError es el error retornado cuando la Policy no permite usar el
+// certificado del emisor de un token.
+type PolicyError struct {
+	Certificate *x509.Certificate
+	Reason      string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("el certificado %q no está permitido: %s", e.Certificate.Subject.String(), e.Reason)
+}
+
+// SignatureError es el error retornado cuando la firma de un token no
+// corresponde a ninguno de los certificados de su emisor.
+type SignatureError struct {
+	Source string
+}
+
+func (e *SignatureError) Error() string {
+	return fmt.Sprintf("la firma del token del emisor %q es inválida", e.Source)
+}
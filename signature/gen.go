@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build ignore
+// +build ignore
+
+// Este programa genera los certificados y vectores de prueba de testdata con
+// las claves de testdata/keys, que se generan si no existen. Con las mismas
+// claves el resultado es siempre el mismo, de modo que puede modificarse
+// para agregar vectores sin alterar los existentes.
+//
+// Se ejecuta con go generate.
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+)
+
+const source = "CN=wsaahomo, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239"
+
+func main() {
+	for _, dir := range []string{"certs", "keys", "vectors"} {
+		if err := os.MkdirAll(filepath.Join("testdata", dir), 0755); err != nil {
+			log.Fatal(err)
+		}
+	}
+	k2019 := certificate("wsaahomo-2019", "wsaahomo", 2048, date(2019, 1, 1), date(2022, 1, 1))
+	k2021 := certificate("wsaahomo-2021", "wsaahomo", 2048, date(2021, 6, 1), date(2041, 6, 1))
+	legado := certificate("legado", "legado", 1024, date(2019, 1, 1), date(2041, 1, 1))
+	vector("2020-sha1", k2019, crypto.SHA1, source, date(2020, 3, 16))
+	vector("2021-sha1", k2019, crypto.SHA1, source, date(2021, 9, 1))
+	vector("2022-sha1", k2021, crypto.SHA1, source, date(2022, 6, 1))
+	vector("2020-sha256", k2019, crypto.SHA256, source, date(2020, 3, 16))
+	vector("2022-viejo", k2019, crypto.SHA1, source, date(2022, 6, 1))
+	vector("legado", legado, crypto.SHA1, "CN=legado, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239", date(2020, 3, 16))
+	vector("desconocido", k2019, crypto.SHA1, "CN=otro, O=AFIP, C=AR", date(2020, 3, 16))
+}
+
+// date retorna el mediodía UTC del día indicado.
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 12, 0, 0, 0, time.UTC)
+}
+
+func write(path string, data []byte) {
+	if err := ioutil.WriteFile(filepath.Join("testdata", path), data, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// key lee la clave name de testdata/keys o la genera con bits bits.
+func key(name string, bits int) *rsa.PrivateKey {
+	path := filepath.Join("keys", name+".key")
+	data, err := ioutil.ReadFile(filepath.Join("testdata", path))
+	if os.IsNotExist(err) {
+		k, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			log.Fatal(err)
+		}
+		write(path, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}))
+		return k
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		log.Fatalf("%s no contiene una clave", path)
+	}
+	k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return k
+}
+
+// certificate escribe un certificado autofirmado del emisor cn vigente
+// entre from y to y retorna su clave.
+func certificate(name, cn string, bits int, from, to time.Time) *rsa.PrivateKey {
+	k := key(name, bits)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(from.Unix() + int64(bits)),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"AFIP"}, Country: []string{"AR"}, SerialNumber: "CUIT 33693450239"},
+		NotBefore:    from,
+		NotAfter:     to,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &k.PublicKey, k)
+	if err != nil {
+		log.Fatal(err)
+	}
+	write(filepath.Join("certs", name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	return k
+}
+
+// vector escribe un token del emisor src generado en gen y válido por 12
+// horas y su firma RSA con la clave k y el hash h.
+func vector(name string, k *rsa.PrivateKey, h crypto.Hash, src string, gen time.Time) {
+	token := []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sso version="2.0"><id src="%s" dst="CN=wsfe, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239" unique_id="%d" gen_time="%d" exp_time="%d"/><operation type="login" value="granted"><login entity="33693450239" service="wsfe" uid="SERIALNUMBER=CUIT 20242643772, CN=prueba" authmethod="cms" regmethod="22"><relations><relation key="20242643772" reltype="4"/></relations></login></operation></sso>
+`, src, gen.Unix()%100000, gen.Unix(), gen.Add(12*time.Hour).Unix()))
+	d := h.New()
+	d.Write(token)
+	sign, err := rsa.SignPKCS1v15(rand.Reader, k, h, d.Sum(nil))
+	if err != nil {
+		log.Fatal(err)
+	}
+	write(filepath.Join("vectors", name+".token"), []byte(base64.StdEncoding.EncodeToString(token)+"\n"))
+	write(filepath.Join("vectors", name+".sign"), []byte(base64.StdEncoding.EncodeToString(sign)+"\n"))
+}
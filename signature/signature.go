@@ -0,0 +1,179 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signature
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	// Registran las funciones de hash que puede permitir una Policy.
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"github.com/pkg/errors"
+
+	"github.com/lalloni/afip/token"
+)
+
+// DefaultMinKeyBits es el tamaño mínimo predeterminado de las claves RSA.
+const DefaultMinKeyBits = 2048
+
+// Policy determina los algoritmos y claves aceptados para verificar firmas.
+type Policy struct {
+	// Hashes son las funciones de hash permitidas para firmas RSA PKCS#1
+	// v1.5, en el orden en que se prueban. Si está vacío sólo se permite
+	// SHA-1, el algoritmo que usa AFIP para firmar tokens.
+	Hashes []crypto.Hash
+	// MinKeyBits es el tamaño mínimo en bits de las claves RSA. Si es 0 se
+	// usa DefaultMinKeyBits.
+	MinKeyBits int
+}
+
+// DefaultPolicy retorna la política predeterminada, que sólo permite firmas
+// RSA con SHA-1 y claves de al menos DefaultMinKeyBits bits.
+func DefaultPolicy() Policy {
+	return Policy{Hashes: []crypto.Hash{crypto.SHA1}, MinKeyBits: DefaultMinKeyBits}
+}
+
+// withDefaults retorna p completando cada campo vacío con su valor
+// predeterminado.
+func (p Policy) withDefaults() Policy {
+	if len(p.Hashes) == 0 {
+		p.Hashes = []crypto.Hash{crypto.SHA1}
+	}
+	if p.MinKeyBits == 0 {
+		p.MinKeyBits = DefaultMinKeyBits
+	}
+	return p
+}
+
+// check verifica que la clave de cert esté permitida y la retorna.
+func (p Policy) check(cert *x509.Certificate) (*rsa.PublicKey, error) {
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, &PolicyError{Certificate: cert, Reason: fmt.Sprintf("la clave %s no es rsa", cert.PublicKeyAlgorithm)}
+	}
+	if bits := key.N.BitLen(); bits < p.MinKeyBits {
+		return nil, &PolicyError{Certificate: cert, Reason: fmt.Sprintf("la clave tiene %d bits (el mínimo es %d)", bits, p.MinKeyBits)}
+	}
+	return key, nil
+}
+
+// Verifier verifica la firma de tokens con los certificados de sus
+// emisores.
+type Verifier struct {
+	// Store provee los certificados de los emisores.
+	Store Store
+	// Policy determina los algoritmos y claves aceptados. Sus campos vacíos
+	// toman los valores de DefaultPolicy.
+	Policy Policy
+}
+
+// NewVerifier construye un Verifier con los certificados de store y la
+// política predeterminada.
+func NewVerifier(store Store) *Verifier {
+	return &Verifier{Store: store, Policy: DefaultPolicy()}
+}
+
+// Verify decodifica el token tok y verifica su firma sign, ambos
+// codificados en base64 tal como los emite AFIP.
+//
+// No verifica el período de validez del token; para eso debe usarse
+// token.Token.Validate.
+func (v *Verifier) Verify(tok, sign string) (*token.Token, error) {
+	t, err := token.Decode(tok)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(sign))
+	if err != nil {
+		return nil, errors.Wrap(err, "decodificando firma del token")
+	}
+	if _, err := v.VerifyToken(t, sig); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// VerifyToken verifica la firma sign del token t, que debe haber sido
+// decodificado con token.Decode o token.Parse, y retorna el certificado que
+// la verificó.
+//
+// Sólo se consideran los certificados del emisor vigentes al momento de
+// generación del token. Los errores son *UnknownSourceError,
+// *ExpiredCertificateError, *PolicyError (si la Policy no permite ninguno
+// de los certificados) o *SignatureError.
+func (v *Verifier) VerifyToken(t *token.Token, sign []byte) (*x509.Certificate, error) {
+	if t.Raw == nil {
+		return nil, errors.New("el token no tiene el xml sobre el que se calculó su firma")
+	}
+	certs, err := v.Store.Certificates(t.Source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "obteniendo certificados del emisor %q", t.Source)
+	}
+	if len(certs) == 0 {
+		return nil, &UnknownSourceError{Source: t.Source}
+	}
+	p := v.Policy.withDefaults()
+	var (
+		current, allowed int
+		policy           error
+	)
+	for _, cert := range certs {
+		if t.GenerationTime.Before(cert.NotBefore) || t.GenerationTime.After(cert.NotAfter) {
+			continue
+		}
+		current++
+		key, err := p.check(cert)
+		if err != nil {
+			if policy == nil {
+				policy = err
+			}
+			continue
+		}
+		allowed++
+		for _, h := range p.Hashes {
+			if !h.Available() {
+				return nil, &PolicyError{Certificate: cert, Reason: fmt.Sprintf("la función de hash %v no está disponible", h)}
+			}
+			d := h.New()
+			d.Write(t.Raw)
+			if rsa.VerifyPKCS1v15(key, h, d.Sum(nil), sign) == nil {
+				return cert, nil
+			}
+		}
+	}
+	switch {
+	case current == 0:
+		return nil, &ExpiredCertificateError{Source: t.Source, Time: t.GenerationTime}
+	case allowed == 0:
+		return nil, policy
+	default:
+		return nil, &SignatureError{Source: t.Source}
+	}
+}
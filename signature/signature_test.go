@@ -0,0 +1,210 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lalloni/afip/token"
+	"github.com/lalloni/afip/wsaa"
+	"github.com/lalloni/afip/wsaa/wsaatest"
+)
+
+//go:generate go run gen.go
+
+// Los vectores de testdata/vectors fueron generados por gen.go y firmados
+// con las claves de testdata/keys de los certificados de testdata/certs:
+//
+//	wsaahomo-2019 vigente de 2019-01-01 a 2022-01-01 (2048 bits)
+//	wsaahomo-2021 vigente de 2021-06-01 a 2041-06-01 (2048 bits)
+//	legado        vigente de 2019-01-01 a 2041-01-01 (1024 bits)
+
+func vector(t *testing.T, name string) (tok, sign string) {
+	t.Helper()
+	bs, err := ioutil.ReadFile(filepath.Join("testdata", "vectors", name+".token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ss, err := ioutil.ReadFile(filepath.Join("testdata", "vectors", name+".sign"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bs), string(ss)
+}
+
+func certificate(t *testing.T, name string) *x509.Certificate {
+	t.Helper()
+	bs, err := ioutil.ReadFile(filepath.Join("testdata", "certs", name+".pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	certs, err := parsePEM(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certs[0]
+}
+
+func TestVerifyVectors(t *testing.T) {
+	store, err := NewDirStore(filepath.Join("testdata", "certs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	permissive := Policy{Hashes: []crypto.Hash{crypto.SHA1, crypto.SHA256}, MinKeyBits: 1024}
+	tests := []struct {
+		vector string
+		policy Policy
+		cert   string
+		err    interface{}
+	}{
+		{"2020-sha1", DefaultPolicy(), "wsaahomo-2019", nil},
+		{"2021-sha1", DefaultPolicy(), "wsaahomo-2019", nil},
+		{"2022-sha1", DefaultPolicy(), "wsaahomo-2021", nil},
+		{"2022-viejo", DefaultPolicy(), "", &SignatureError{}},
+		{"2020-sha256", DefaultPolicy(), "", &SignatureError{}},
+		{"2020-sha256", permissive, "wsaahomo-2019", nil},
+		{"legado", DefaultPolicy(), "", &PolicyError{}},
+		{"legado", Policy{}, "", &PolicyError{}},
+		{"legado", Policy{Hashes: []crypto.Hash{crypto.SHA1}}, "", &PolicyError{}},
+		{"2020-sha1", Policy{MinKeyBits: 1024}, "wsaahomo-2019", nil},
+		{"2020-sha1", Policy{MinKeyBits: 4096}, "", &PolicyError{}},
+		{"2020-sha256", Policy{MinKeyBits: 1024}, "", &SignatureError{}},
+		{"2020-sha1", Policy{Hashes: []crypto.Hash{crypto.MD4, crypto.SHA1}}, "", &PolicyError{}},
+		{"legado", permissive, "legado", nil},
+		{"desconocido", DefaultPolicy(), "", &UnknownSourceError{}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.vector, func(t *testing.T) {
+			tok, sign := vector(t, test.vector)
+			v := &Verifier{Store: store, Policy: test.policy}
+			tt, err := token.Decode(tok)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(sign))
+			cert, err := v.VerifyToken(tt, sig)
+			if test.err != nil {
+				target := reflect.New(reflect.TypeOf(test.err)).Interface()
+				if !stderrors.As(err, target) {
+					t.Errorf("VerifyToken() error = %v, want %T", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyToken() error = %v", err)
+			}
+			if !cert.Equal(certificate(t, test.cert)) {
+				t.Errorf("VerifyToken() = %v, want %s", cert.Subject, test.cert)
+			}
+			if got, err := v.Verify(tok, sign); err != nil || got.Login.CUIT != 20242643772 {
+				t.Errorf("Verify() = %v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	v := NewVerifier(NewMemoryStore(certificate(t, "wsaahomo-2021")))
+	tok, sign := vector(t, "2020-sha1")
+	var expired *ExpiredCertificateError
+	if _, err := v.Verify(tok, sign); !stderrors.As(err, &expired) || expired.Time.Year() != 2020 {
+		t.Errorf("Verify() error = %v, want *ExpiredCertificateError", err)
+	}
+	v = NewVerifier(NewMemoryStore(certificate(t, "wsaahomo-2019")))
+	raw, _ := base64.StdEncoding.DecodeString(strings.TrimSpace(tok))
+	tampered := base64.StdEncoding.EncodeToString([]byte(strings.Replace(string(raw), `service="wsfe"`, `service="wsfx"`, 1)))
+	var invalid *SignatureError
+	if _, err := v.Verify(tampered, sign); !stderrors.As(err, &invalid) {
+		t.Errorf("Verify() error = %v, want *SignatureError", err)
+	}
+	if _, err := v.Verify(tok, "no es base64"); err == nil {
+		t.Error("Verify() expected error")
+	}
+	var format *token.FormatError
+	if _, err := v.Verify("no es base64", sign); !stderrors.As(err, &format) {
+		t.Errorf("Verify() error = %v, want *token.FormatError", err)
+	}
+	tt, err := token.Decode(tok)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt.Raw = nil
+	if _, err := v.VerifyToken(tt, nil); err == nil {
+		t.Error("VerifyToken() expected error")
+	}
+}
+
+func TestVerifyWSAA(t *testing.T) {
+	ca, err := wsaatest.NewCA("AC de prueba")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := wsaatest.NewCertificate(ca, "prueba", "20242643772", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := wsaatest.NewServer(ca.Certificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	signer, err := wsaa.NewSigner(leaf.Certificate, leaf.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta, err := srv.Client(signer).Login(context.Background(), "wsfe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := NewVerifier(NewMemoryStore(srv.Certificate)).Verify(ta.Token, ta.Sign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tok.Validate(time.Now(), token.DefaultSkew); err != nil || !tok.Represents(20242643772) {
+		t.Errorf("Verify() = %+v, %v", tok, err)
+	}
+}
+
+func ExampleVerifier() {
+	store, err := NewDirStore("testdata/certs")
+	if err != nil {
+		panic(err)
+	}
+	tok, _ := ioutil.ReadFile("testdata/vectors/2022-sha1.token")
+	sign, _ := ioutil.ReadFile("testdata/vectors/2022-sha1.sign")
+	t, err := NewVerifier(store).Verify(string(tok), string(sign))
+	fmt.Println(t.Login.Service, t.Login.CUIT, err)
+	// Output: wsfe 20-24264377-2 <nil>
+}
@@ -0,0 +1,231 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signature
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Store provee los certificados de los emisores de tokens.
+type Store interface {
+	// Certificates retorna los certificados cuyo sujeto es el DN dn, que
+	// se compara sin tener en cuenta el orden de sus atributos, las
+	// mayúsculas ni los espacios. Si no hay ninguno retorna nil y ningún
+	// error.
+	Certificates(dn string) ([]*x509.Certificate, error)
+}
+
+// MemoryStore es un Store en memoria.
+//
+// Puede usarse concurrentemente desde varias goroutines.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	certs map[string][]*x509.Certificate
+}
+
+// NewMemoryStore construye un MemoryStore con los certificados certs.
+func NewMemoryStore(certs ...*x509.Certificate) *MemoryStore {
+	s := &MemoryStore{certs: map[string][]*x509.Certificate{}}
+	s.Add(certs...)
+	return s
+}
+
+// Add agrega los certificados certs, ignorando los que ya estaban.
+func (s *MemoryStore) Add(certs ...*x509.Certificate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cert := range certs {
+		k := normalize(cert.Subject.String())
+		if index(s.certs[k], cert) < 0 {
+			s.certs[k] = append(s.certs[k], cert)
+		}
+	}
+}
+
+// Remove quita el certificado cert, por ejemplo al revocarlo.
+func (s *MemoryStore) Remove(cert *x509.Certificate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := normalize(cert.Subject.String())
+	cs := s.certs[k]
+	if i := index(cs, cert); i >= 0 {
+		cs = append(cs[:i:i], cs[i+1:]...)
+	}
+	if len(cs) == 0 {
+		delete(s.certs, k)
+		return
+	}
+	s.certs[k] = cs
+}
+
+// Certificates implementa Store.
+func (s *MemoryStore) Certificates(dn string) ([]*x509.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cs := s.certs[normalize(dn)]
+	if len(cs) == 0 {
+		return nil, nil
+	}
+	return append([]*x509.Certificate(nil), cs...), nil
+}
+
+func index(cs []*x509.Certificate, cert *x509.Certificate) int {
+	for i, c := range cs {
+		if c.Equal(cert) {
+			return i
+		}
+	}
+	return -1
+}
+
+// DirStore es un Store con los certificados codificados en PEM de los
+// archivos con extensión .pem, .crt o .cer de un directorio.
+//
+// Puede usarse concurrentemente desde varias goroutines.
+type DirStore struct {
+	dir string
+	mu  sync.RWMutex
+	mem *MemoryStore
+}
+
+// NewDirStore construye un DirStore con los certificados del directorio
+// dir.
+func NewDirStore(dir string) (*DirStore, error) {
+	s := &DirStore{dir: dir}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload vuelve a leer los certificados del directorio, por ejemplo después
+// de agregar el certificado nuevo de un emisor. Si falla se conservan los
+// certificados leídos anteriormente.
+func (s *DirStore) Reload() error {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return errors.Wrapf(err, "leyendo directorio de certificados %s", s.dir)
+	}
+	mem := NewMemoryStore()
+	for _, info := range infos {
+		switch strings.ToLower(filepath.Ext(info.Name())) {
+		case ".pem", ".crt", ".cer":
+		default:
+			continue
+		}
+		if info.IsDir() {
+			continue
+		}
+		path := filepath.Join(s.dir, info.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "leyendo certificados de %s", path)
+		}
+		certs, err := parsePEM(data)
+		if err != nil {
+			return errors.Wrapf(err, "leyendo certificados de %s", path)
+		}
+		mem.Add(certs...)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mem = mem
+	return nil
+}
+
+// Certificates implementa Store.
+func (s *DirStore) Certificates(dn string) ([]*x509.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mem.Certificates(dn)
+}
+
+// parsePEM decodifica los bloques CERTIFICATE de data, ignorando los demás.
+func parsePEM(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "decodificando certificado")
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no contiene certificados")
+	}
+	return certs, nil
+}
+
+// normalize retorna una forma canónica del DN dn: sus atributos ordenados,
+// con el tipo en mayúsculas y el valor en minúsculas y con los espacios
+// normalizados.
+func normalize(dn string) string {
+	var (
+		attrs   []string
+		cur     strings.Builder
+		escaped bool
+	)
+	push := func() {
+		kv := strings.SplitN(cur.String(), "=", 2)
+		cur.Reset()
+		if len(kv) != 2 {
+			return
+		}
+		t := strings.ToUpper(strings.TrimSpace(kv[0]))
+		v := strings.ToLower(strings.Join(strings.Fields(kv[1]), " "))
+		if t != "" {
+			attrs = append(attrs, t+"="+v)
+		}
+	}
+	for _, r := range dn {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',' || r == '+' || r == ';':
+			push()
+			continue
+		}
+		cur.WriteRune(r)
+	}
+	push()
+	sort.Strings(attrs)
+	return strings.Join(attrs, ",")
+}
@@ -0,0 +1,117 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signature
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"CN=wsaahomo, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239", "SERIALNUMBER=CUIT 33693450239,CN=wsaahomo,O=AFIP,C=AR"},
+		{"cn=WSAAHOMO,o=afip,c=ar", "CN=wsaahomo, O=AFIP, C=AR"},
+		{"CN=Juan  Pérez, O=AFIP", "O=AFIP,CN=juan pérez"},
+		{`CN=a\, b, O=AFIP`, `O=AFIP, CN=a\, b`},
+	}
+	for _, test := range tests {
+		if a, b := normalize(test.a), normalize(test.b); a != b {
+			t.Errorf("normalize(%q) = %q, normalize(%q) = %q", test.a, a, test.b, b)
+		}
+	}
+	if normalize(`CN=a\, b`) == normalize("CN=a, b") {
+		t.Error("normalize() debe respetar las comas escapadas")
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	c19, c21 := certificate(t, "wsaahomo-2019"), certificate(t, "wsaahomo-2021")
+	s := NewMemoryStore(c19, c19)
+	s.Add(c21)
+	dn := "CN=wsaahomo, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239"
+	if cs, err := s.Certificates(dn); err != nil || len(cs) != 2 {
+		t.Errorf("Certificates() = %v, %v", cs, err)
+	}
+	s.Remove(c19)
+	if cs, err := s.Certificates(dn); err != nil || len(cs) != 1 || !cs[0].Equal(c21) {
+		t.Errorf("Certificates() = %v, %v", cs, err)
+	}
+	s.Remove(c21)
+	if cs, err := s.Certificates(dn); err != nil || cs != nil {
+		t.Errorf("Certificates() = %v, %v", cs, err)
+	}
+}
+
+func TestDirStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	install := func(name, as string) {
+		bs, err := ioutil.ReadFile(filepath.Join("testdata", "certs", name+".pem"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, as), bs, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	install("wsaahomo-2019", "wsaa.crt")
+	if err := ioutil.WriteFile(filepath.Join(dir, "LEAME.txt"), []byte("ignorado"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewDirStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dn := "CN=wsaahomo, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239"
+	if cs, _ := s.Certificates(dn); len(cs) != 1 {
+		t.Errorf("Certificates() = %v", cs)
+	}
+	// Rotación: se agrega el certificado nuevo.
+	install("wsaahomo-2021", "wsaa-2021.PEM")
+	if err := s.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if cs, _ := s.Certificates(dn); len(cs) != 2 {
+		t.Errorf("Certificates() = %v", cs)
+	}
+	// Un archivo inválido no reemplaza los certificados leídos.
+	if err := ioutil.WriteFile(filepath.Join(dir, "roto.pem"), []byte("basura"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Reload(); err == nil {
+		t.Error("Reload() expected error")
+	}
+	if cs, _ := s.Certificates(dn); len(cs) != 2 {
+		t.Errorf("Certificates() = %v", cs)
+	}
+	if _, err := NewDirStore(filepath.Join(dir, "inexistente")); err == nil {
+		t.Error("NewDirStore() expected error")
+	}
+}
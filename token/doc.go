@@ -0,0 +1,47 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package token permite decodificar, validar y generar los tokens de
+// autenticación emitidos por WSAA, SUA y Clave Fiscal.
+//
+// Un token es un documento XML sso codificado en base64 que identifica a su
+// emisor, su destinatario y su período de validez y describe el login
+// concedido:
+//
+//	<sso version="2.0">
+//	  <id src="CN=wsaahomo, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239"
+//	      dst="CN=wsfe, O=AFIP, C=AR" unique_id="3614526941"
+//	      gen_time="1584360000" exp_time="1584403200"/>
+//	  <operation type="login" value="granted">
+//	    <login entity="33693450239" service="wsfe"
+//	           uid="SERIALNUMBER=CUIT 20242643772, CN=prueba"
+//	           authmethod="cms" regmethod="22">
+//	      <relations>
+//	        <relation key="20242643772" reltype="4"/>
+//	      </relations>
+//	    </login>
+//	  </operation>
+//	</sso>
+//
+// Decode no verifica la firma del token; para eso debe usarse el paquete
+// signature.
+package token
@@ -0,0 +1,88 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package token
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatError es el error retornado cuando un token no puede decodificarse.
+type FormatError struct {
+	Err error
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("formato de token incorrecto: %v", e.Err)
+}
+
+// Unwrap retorna la causa del error.
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError es el error retornado cuando un campo de un token falta o
+// tiene un valor inválido.
+type FieldError struct {
+	Field string
+	Value string
+}
+
+func (e *FieldError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("el token no tiene %s", e.Field)
+	}
+	return fmt.Sprintf("valor inválido para %s del token: %q", e.Field, e.Value)
+}
+
+// DeniedError es el error retornado cuando la operación descripta por un
+// token no fue concedida.
+type DeniedError struct {
+	Operation string
+	Value     string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("la operación %s del token no fue concedida (%s)", e.Operation, e.Value)
+}
+
+// ExpiredError es el error retornado cuando un token ya venció.
+type ExpiredError struct {
+	ExpirationTime time.Time
+	Now            time.Time
+}
+
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("el token venció el %s", e.ExpirationTime.Format(time.RFC3339))
+}
+
+// NotYetValidError es el error retornado cuando un token todavía no es
+// válido.
+type NotYetValidError struct {
+	GenerationTime time.Time
+	Now            time.Time
+}
+
+func (e *NotYetValidError) Error() string {
+	return fmt.Sprintf("el token no es válido hasta el %s", e.GenerationTime.Format(time.RFC3339))
+}
@@ -0,0 +1,353 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package token
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lalloni/afip/cuit"
+)
+
+// DefaultSkew es la tolerancia sugerida para las diferencias entre el reloj
+// local y el del emisor de los tokens.
+const DefaultSkew = 5 * time.Minute
+
+// Granted es el valor de una operación concedida.
+const Granted = "granted"
+
+// Token es un token de autenticación.
+type Token struct {
+	// Version es la versión del formato del token.
+	Version string
+	// Source es el DN del emisor del token.
+	Source string
+	// Destination es el DN del servicio destinatario del token.
+	Destination string
+	// UniqueID es el identificador del token asignado por el emisor.
+	UniqueID uint32
+	// GenerationTime es el instante a partir del cual es válido el token.
+	GenerationTime time.Time
+	// ExpirationTime es el instante hasta el cual es válido el token.
+	ExpirationTime time.Time
+	// Operation es el tipo de operación, normalmente "login".
+	Operation string
+	// Value es el resultado de la operación, normalmente Granted.
+	Value string
+	// Login describe al usuario autenticado.
+	Login Login
+	// Raw es el XML del token tal como fue decodificado, sobre el cual se
+	// calcula su firma. Es nil en los tokens construidos por el usuario.
+	Raw []byte
+}
+
+// Login describe al usuario autenticado por un token.
+type Login struct {
+	// Entity es el CUIT de la entidad que autenticó al usuario.
+	Entity cuit.CUIT
+	// Service es el nombre del servicio al que se concedió acceso.
+	Service string
+	// UID identifica al usuario: un CUIT o el DN de su certificado.
+	UID string
+	// AuthMethod es el método de autenticación, por ejemplo "cms" o
+	// "cuit".
+	AuthMethod string
+	// RegMethod es el método de registración del usuario.
+	RegMethod string
+	// Level es el nivel de seguridad de la clave fiscal del usuario o 0 si
+	// el token no lo informa.
+	Level uint
+	// CUIT es el CUIT del usuario. Si el token no lo informa
+	// explícitamente se obtiene de UID cuando éste es un CUIT o un DN con
+	// serialNumber "CUIT n".
+	CUIT cuit.CUIT
+	// Relations son los contribuyentes representados por el usuario.
+	Relations []Relation
+}
+
+// Relation es una relación entre el usuario y un contribuyente al que
+// representa.
+type Relation struct {
+	// Key es el CUIT del contribuyente representado.
+	Key cuit.CUIT
+	// RelType es el tipo de relación.
+	RelType uint
+}
+
+type sso struct {
+	XMLName xml.Name `xml:"sso"`
+	Version string   `xml:"version,attr"`
+	ID      struct {
+		Source      string `xml:"src,attr"`
+		Destination string `xml:"dst,attr"`
+		UniqueID    string `xml:"unique_id,attr"`
+		GenTime     string `xml:"gen_time,attr"`
+		ExpTime     string `xml:"exp_time,attr"`
+	} `xml:"id"`
+	Operation struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:"value,attr"`
+		Login struct {
+			Entity     string     `xml:"entity,attr"`
+			Service    string     `xml:"service,attr"`
+			UID        string     `xml:"uid,attr"`
+			AuthMethod string     `xml:"authmethod,attr"`
+			RegMethod  string     `xml:"regmethod,attr"`
+			Level      string     `xml:"level,attr,omitempty"`
+			CUIT       string     `xml:"cuit,attr,omitempty"`
+			Relations  []relation `xml:"relations>relation"`
+		} `xml:"login"`
+	} `xml:"operation"`
+}
+
+type relation struct {
+	Key     string `xml:"key,attr"`
+	RelType string `xml:"reltype,attr"`
+}
+
+// Decode decodifica un token codificado en base64.
+func Decode(s string) (*Token, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, &FormatError{Err: err}
+	}
+	return Parse(data)
+}
+
+// Parse decodifica el XML de un token verificando que tenga emisor, período
+// de validez, operación y servicio y que sus CUIT sean válidos.
+//
+// Los errores son *FormatError o *FieldError.
+func Parse(data []byte) (*Token, error) {
+	var x sso
+	if err := xml.Unmarshal(data, &x); err != nil {
+		return nil, &FormatError{Err: err}
+	}
+	t := &Token{
+		Version:     x.Version,
+		Source:      x.ID.Source,
+		Destination: x.ID.Destination,
+		Operation:   x.Operation.Type,
+		Value:       x.Operation.Value,
+		Raw:         data,
+	}
+	l := x.Operation.Login
+	t.Login = Login{
+		Service:    l.Service,
+		UID:        l.UID,
+		AuthMethod: l.AuthMethod,
+		RegMethod:  l.RegMethod,
+	}
+	var err error
+	if t.Source == "" {
+		return nil, &FieldError{Field: "emisor"}
+	}
+	if x.ID.UniqueID != "" {
+		n, err := strconv.ParseUint(x.ID.UniqueID, 10, 32)
+		if err != nil {
+			return nil, &FieldError{Field: "identificador", Value: x.ID.UniqueID}
+		}
+		t.UniqueID = uint32(n)
+	}
+	if t.GenerationTime, err = instant("momento de generación", x.ID.GenTime); err != nil {
+		return nil, err
+	}
+	if t.ExpirationTime, err = instant("vencimiento", x.ID.ExpTime); err != nil {
+		return nil, err
+	}
+	if !t.ExpirationTime.After(t.GenerationTime) {
+		return nil, &FieldError{Field: "vencimiento", Value: x.ID.ExpTime}
+	}
+	if t.Operation == "" {
+		return nil, &FieldError{Field: "operación"}
+	}
+	if t.Login.Service == "" {
+		return nil, &FieldError{Field: "servicio"}
+	}
+	if t.Login.Entity, err = number("entidad", l.Entity); err != nil {
+		return nil, err
+	}
+	if l.Level != "" {
+		n, err := strconv.ParseUint(l.Level, 10, 8)
+		if err != nil {
+			return nil, &FieldError{Field: "nivel", Value: l.Level}
+		}
+		t.Login.Level = uint(n)
+	}
+	switch {
+	case l.CUIT != "":
+		if t.Login.CUIT, err = number("cuit", l.CUIT); err != nil {
+			return nil, err
+		}
+	default:
+		t.Login.CUIT = uidCUIT(l.UID)
+	}
+	for _, r := range l.Relations {
+		k, err := number("cuit de la relación", r.Key)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseUint(r.RelType, 10, 32)
+		if err != nil {
+			return nil, &FieldError{Field: "tipo de relación", Value: r.RelType}
+		}
+		t.Login.Relations = append(t.Login.Relations, Relation{Key: k, RelType: uint(n)})
+	}
+	return t, nil
+}
+
+// instant decodifica un instante expresado en segundos desde la época Unix.
+func instant(field, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, &FieldError{Field: field}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}, &FieldError{Field: field, Value: s}
+	}
+	return time.Unix(n, 0), nil
+}
+
+// number decodifica un CUIT sin separadores.
+func number(field, s string) (cuit.CUIT, error) {
+	if s == "" {
+		return 0, &FieldError{Field: field}
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || !cuit.IsValid(n) {
+		return 0, &FieldError{Field: field, Value: s}
+	}
+	return cuit.CUIT(n), nil
+}
+
+// uidCUIT obtiene el CUIT de un uid que es un CUIT o un DN con serialNumber
+// "CUIT n" o retorna 0 si no lo tiene.
+func uidCUIT(uid string) cuit.CUIT {
+	if n, err := strconv.ParseUint(uid, 10, 64); err == nil && cuit.IsValid(n) {
+		return cuit.CUIT(n)
+	}
+	for _, rdn := range strings.Split(uid, ",") {
+		kv := strings.SplitN(strings.TrimSpace(rdn), "=", 2)
+		if len(kv) != 2 || !strings.EqualFold(kv[0], "SERIALNUMBER") {
+			continue
+		}
+		v := strings.TrimSpace(kv[1])
+		if len(v) > 5 && strings.EqualFold(v[:5], "CUIT ") {
+			if n, err := strconv.ParseUint(strings.TrimSpace(v[5:]), 10, 64); err == nil && cuit.IsValid(n) {
+				return cuit.CUIT(n)
+			}
+		}
+	}
+	return 0
+}
+
+// Marshal retorna el XML del token construido a partir de sus campos,
+// ignorando Raw.
+func (t *Token) Marshal() ([]byte, error) {
+	var x sso
+	x.Version = t.Version
+	if x.Version == "" {
+		x.Version = "2.0"
+	}
+	x.ID.Source = t.Source
+	x.ID.Destination = t.Destination
+	x.ID.UniqueID = strconv.FormatUint(uint64(t.UniqueID), 10)
+	x.ID.GenTime = strconv.FormatInt(t.GenerationTime.Unix(), 10)
+	x.ID.ExpTime = strconv.FormatInt(t.ExpirationTime.Unix(), 10)
+	x.Operation.Type = t.Operation
+	x.Operation.Value = t.Value
+	l := &x.Operation.Login
+	l.Entity = strconv.FormatUint(t.Login.Entity.Uint64(), 10)
+	l.Service = t.Login.Service
+	l.UID = t.Login.UID
+	l.AuthMethod = t.Login.AuthMethod
+	l.RegMethod = t.Login.RegMethod
+	if t.Login.Level != 0 {
+		l.Level = strconv.FormatUint(uint64(t.Login.Level), 10)
+	}
+	if !t.Login.CUIT.IsZero() && t.Login.CUIT != uidCUIT(t.Login.UID) {
+		l.CUIT = strconv.FormatUint(t.Login.CUIT.Uint64(), 10)
+	}
+	for _, r := range t.Login.Relations {
+		l.Relations = append(l.Relations, relation{
+			Key:     strconv.FormatUint(r.Key.Uint64(), 10),
+			RelType: strconv.FormatUint(uint64(r.RelType), 10),
+		})
+	}
+	bs, err := xml.Marshal(&x)
+	if err != nil {
+		return nil, &FormatError{Err: err}
+	}
+	return append([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+"\n"), bs...), nil
+}
+
+// Encode retorna el XML del token codificado en base64. Si el token tiene
+// Raw se codifica Raw, de modo que se preserve su firma.
+func (t *Token) Encode() (string, error) {
+	data := t.Raw
+	if data == nil {
+		var err error
+		if data, err = t.Marshal(); err != nil {
+			return "", err
+		}
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Validate verifica que la operación del token haya sido concedida y que el
+// token sea válido en el instante now, tolerando una diferencia de hasta
+// skew entre el reloj local y el del emisor.
+//
+// Los errores son *DeniedError, *NotYetValidError o *ExpiredError.
+func (t *Token) Validate(now time.Time, skew time.Duration) error {
+	switch {
+	case t.Value != Granted:
+		return &DeniedError{Operation: t.Operation, Value: t.Value}
+	case now.Add(skew).Before(t.GenerationTime):
+		return &NotYetValidError{GenerationTime: t.GenerationTime, Now: now}
+	case now.Add(-skew).After(t.ExpirationTime):
+		return &ExpiredError{ExpirationTime: t.ExpirationTime, Now: now}
+	default:
+		return nil
+	}
+}
+
+// Represents informa si el usuario del token es el contribuyente c o lo
+// representa según sus relaciones.
+func (t *Token) Represents(c cuit.CUIT) bool {
+	if c.IsZero() {
+		return false
+	}
+	if t.Login.CUIT == c {
+		return true
+	}
+	for _, r := range t.Login.Relations {
+		if r.Key == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package token
+
+import (
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lalloni/afip/cuit"
+)
+
+const wsaaToken = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sso version="2.0">
+    <id src="CN=wsaahomo, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239" dst="CN=wsfe, O=AFIP, C=AR" unique_id="3614526941" gen_time="1584360000" exp_time="1584403200"/>
+    <operation type="login" value="granted">
+        <login entity="33693450239" service="wsfe" uid="SERIALNUMBER=CUIT 20242643772, CN=prueba" authmethod="cms" regmethod="22">
+            <relations>
+                <relation key="20242643772" reltype="4"/>
+                <relation key="30500010912" reltype="4"/>
+            </relations>
+        </login>
+    </operation>
+</sso>
+`
+
+const claveFiscalToken = `<sso version="2.0">
+    <id src="CN=sua, O=AFIP, C=AR" dst="CN=app, O=AFIP, C=AR" unique_id="1" gen_time="1584360000" exp_time="1584363600"/>
+    <operation type="login" value="granted">
+        <login entity="33693450239" service="app" uid="20242643772" authmethod="cuit" regmethod="22" level="3">
+            <relations>
+                <relation key="20242643772" reltype="4"/>
+            </relations>
+        </login>
+    </operation>
+</sso>`
+
+func TestParse(t *testing.T) {
+	tok, err := Parse([]byte(wsaaToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Token{
+		Version:        "2.0",
+		Source:         "CN=wsaahomo, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239",
+		Destination:    "CN=wsfe, O=AFIP, C=AR",
+		UniqueID:       3614526941,
+		GenerationTime: time.Unix(1584360000, 0),
+		ExpirationTime: time.Unix(1584403200, 0),
+		Operation:      "login",
+		Value:          Granted,
+		Login: Login{
+			Entity:     33693450239,
+			Service:    "wsfe",
+			UID:        "SERIALNUMBER=CUIT 20242643772, CN=prueba",
+			AuthMethod: "cms",
+			RegMethod:  "22",
+			CUIT:       20242643772,
+			Relations:  []Relation{{Key: 20242643772, RelType: 4}, {Key: 30500010912, RelType: 4}},
+		},
+		Raw: []byte(wsaaToken),
+	}
+	if !reflect.DeepEqual(tok, want) {
+		t.Errorf("Parse() = %+v, want %+v", tok, want)
+	}
+	tok, err = Decode(base64.StdEncoding.EncodeToString([]byte(claveFiscalToken)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.Login.Level != 3 || tok.Login.CUIT != 20242643772 || tok.Login.AuthMethod != "cuit" {
+		t.Errorf("Decode() = %+v", tok.Login)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		field string
+	}{
+		{"xml", "<sso>", ""},
+		{"source", `<sso><id gen_time="1" exp_time="2"/></sso>`, "emisor"},
+		{"unique id", `<sso><id src="a" unique_id="x" gen_time="1" exp_time="2"/></sso>`, "identificador"},
+		{"no gen", `<sso><id src="a" exp_time="2"/></sso>`, "momento de generación"},
+		{"bad exp", `<sso><id src="a" gen_time="1" exp_time="mañana"/></sso>`, "vencimiento"},
+		{"exp before gen", `<sso><id src="a" gen_time="2" exp_time="1"/></sso>`, "vencimiento"},
+		{"operation", `<sso><id src="a" gen_time="1" exp_time="2"/></sso>`, "operación"},
+		{"service", `<sso><id src="a" gen_time="1" exp_time="2"/><operation type="login"><login entity="33693450239"/></operation></sso>`, "servicio"},
+		{"entity", `<sso><id src="a" gen_time="1" exp_time="2"/><operation type="login"><login entity="33693450230" service="s"/></operation></sso>`, "entidad"},
+		{"level", `<sso><id src="a" gen_time="1" exp_time="2"/><operation type="login"><login entity="33693450239" service="s" level="alto"/></operation></sso>`, "nivel"},
+		{"cuit", `<sso><id src="a" gen_time="1" exp_time="2"/><operation type="login"><login entity="33693450239" service="s" cuit="20-24264377-2"/></operation></sso>`, "cuit"},
+		{"relation", `<sso><id src="a" gen_time="1" exp_time="2"/><operation type="login"><login entity="33693450239" service="s"><relations><relation key="20242643773" reltype="4"/></relations></login></operation></sso>`, "cuit de la relación"},
+		{"reltype", `<sso><id src="a" gen_time="1" exp_time="2"/><operation type="login"><login entity="33693450239" service="s"><relations><relation key="20242643772"/></relations></login></operation></sso>`, "tipo de relación"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.data))
+			var fe *FieldError
+			var fo *FormatError
+			switch {
+			case test.field == "" && !stderrors.As(err, &fo):
+				t.Errorf("Parse() error = %v, want *FormatError", err)
+			case test.field != "" && (!stderrors.As(err, &fe) || fe.Field != test.field):
+				t.Errorf("Parse() error = %v, want *FieldError %q", err, test.field)
+			}
+		})
+	}
+	for _, test := range []struct{ data, want string }{
+		{`<sso><id src="a" gen_time="1" exp_time="2"/><operation type="login"><login entity="33693450230" service="s"/></operation></sso>`, `valor inválido para entidad del token: "33693450230"`},
+		{`<sso><id src="a" unique_id="x" gen_time="1" exp_time="2"/></sso>`, `valor inválido para identificador del token: "x"`},
+		{`<sso><id src="a" gen_time="1" exp_time="2"/></sso>`, "el token no tiene operación"},
+	} {
+		if _, err := Parse([]byte(test.data)); err == nil || err.Error() != test.want {
+			t.Errorf("Parse() error = %v, want %q", err, test.want)
+		}
+	}
+	var fo *FormatError
+	if _, err := Decode("no es base64"); !stderrors.As(err, &fo) {
+		t.Errorf("Decode() error = %v, want *FormatError", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tok, err := Parse([]byte(wsaaToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		expired  *ExpiredError
+		notYet   *NotYetValidError
+		denied   *DeniedError
+		gen, exp = tok.GenerationTime, tok.ExpirationTime
+	)
+	if err := tok.Validate(gen, 0); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := tok.Validate(gen.Add(-time.Minute), DefaultSkew); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := tok.Validate(gen.Add(-time.Minute), 0); !stderrors.As(err, &notYet) || !notYet.GenerationTime.Equal(gen) {
+		t.Errorf("Validate() error = %v, want *NotYetValidError", err)
+	}
+	if err := tok.Validate(exp.Add(time.Minute), DefaultSkew); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := tok.Validate(exp.Add(DefaultSkew+time.Second), DefaultSkew); !stderrors.As(err, &expired) || !expired.ExpirationTime.Equal(exp) {
+		t.Errorf("Validate() error = %v, want *ExpiredError", err)
+	}
+	tok.Value = "denied"
+	if err := tok.Validate(gen, 0); !stderrors.As(err, &denied) || err.Error() != "la operación login del token no fue concedida (denied)" {
+		t.Errorf("Validate() error = %v, want *DeniedError", err)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	for _, data := range []string{wsaaToken, claveFiscalToken} {
+		tok, err := Parse([]byte(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tok.Raw = nil
+		s, err := tok.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Decode(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got.Raw = nil
+		if !reflect.DeepEqual(got, tok) {
+			t.Errorf("Decode(Encode()) = %+v, want %+v", got, tok)
+		}
+	}
+	tok := &Token{Source: "CN=sua", GenerationTime: time.Unix(1, 0), ExpirationTime: time.Unix(2, 0), Operation: "login", Value: Granted,
+		Login: Login{Entity: 33693450239, Service: "s", UID: "juan", CUIT: 20242643772}}
+	bs, err := tok.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(bs)
+	if err != nil || got.Login.CUIT != 20242643772 || got.Version != "2.0" {
+		t.Errorf("Parse(Marshal()) = %+v, %v", got, err)
+	}
+}
+
+func TestRepresents(t *testing.T) {
+	tok, err := Parse([]byte(wsaaToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for c, want := range map[cuit.CUIT]bool{20242643772: true, 30500010912: true, 33693450239: false, 0: false} {
+		if got := tok.Represents(c); got != want {
+			t.Errorf("Represents(%v) = %v, want %v", c, got, want)
+		}
+	}
+}
+
+func ExampleDecode() {
+	tok, err := Decode(base64.StdEncoding.EncodeToString([]byte(wsaaToken)))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(tok.Login.Service, tok.Login.CUIT, tok.ExpirationTime.UTC().Format(time.RFC3339))
+	var expired *ExpiredError
+	fmt.Println(stderrors.As(tok.Validate(tok.ExpirationTime.Add(time.Hour), DefaultSkew), &expired))
+	// Output:
+	// wsfe 20-24264377-2 2020-03-17T00:00:00Z
+	// true
+}
@@ -0,0 +1,63 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vencimientos
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Calendario determina qué días son hábiles.
+type Calendario interface {
+	// IsBusinessDay informa si el día de t es hábil.
+	IsBusinessDay(t time.Time) bool
+}
+
+// FinesDeSemana es el Calendario en el que son hábiles todos los días
+// excepto los sábados y domingos.
+var FinesDeSemana Calendario = finesDeSemana{}
+
+type finesDeSemana struct{}
+
+func (finesDeSemana) IsBusinessDay(t time.Time) bool {
+	d := t.Weekday()
+	return d != time.Saturday && d != time.Sunday
+}
+
+// maxNoHabiles es la máxima cantidad de días consecutivos no hábiles que
+// se recorren buscando un día hábil, para no entrar en un ciclo infinito con
+// un Calendario que no tenga ninguno.
+const maxNoHabiles = 366
+
+// nextBusinessDay retorna t si es un día hábil según c o el primer día hábil
+// posterior, con error si no hay ninguno en los maxNoHabiles días
+// siguientes.
+func nextBusinessDay(c Calendario, t time.Time) (time.Time, error) {
+	for i := 0; i <= maxNoHabiles; i++ {
+		if d := t.AddDate(0, 0, i); c.IsBusinessDay(d) {
+			return d, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("no hay días hábiles en los %d días siguientes al %s", maxNoHabiles, t.Format("2006-01-02"))
+}
@@ -0,0 +1,235 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vencimientos
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lalloni/afip/cuit"
+	"github.com/lalloni/afip/feriados"
+	"github.com/lalloni/afip/periodo"
+)
+
+// Obligacion identifica una obligación fiscal.
+//
+// Se definen constantes para las más comunes pero puede usarse cualquier
+// otro valor siempre que coincida con el usado en los archivos cargados.
+type Obligacion string
+
+// Obligaciones más comunes.
+const (
+	IVA              Obligacion = "IVA"
+	Ganancias        Obligacion = "GANANCIAS"
+	BienesPersonales Obligacion = "BIENES PERSONALES"
+	Autonomos        Obligacion = "AUTONOMOS"
+	SICOSS           Obligacion = "SICOSS"
+	Monotributo      Obligacion = "MONOTRIBUTO"
+)
+
+// NotFoundError es el error retornado cuando el cronograma no tiene la
+// fecha de vencimiento solicitada.
+type NotFoundError struct {
+	Obligacion  Obligacion
+	Periodo     periodo.Periodo
+	Terminacion uint
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("no hay vencimiento de %s para el período %s y la terminación de cuit %d", e.Obligacion, e.Periodo, e.Terminacion)
+}
+
+// clave identifica una fecha de vencimiento en el cronograma.
+type clave struct {
+	obligacion  Obligacion
+	periodo     periodo.Periodo
+	terminacion uint
+}
+
+// Cronograma contiene las fechas de vencimiento de las obligaciones
+// fiscales según el período y la terminación del CUIT.
+//
+// Sus métodos no pueden usarse concurrentemente con Add, Load o LoadFile.
+type Cronograma struct {
+	calendario Calendario
+	fechas     map[clave]time.Time
+}
+
+// New construye un cronograma vacío que traslada los vencimientos a días
+// hábiles según el calendario c o, si c es nil, según los fines de semana y
+// los feriados nacionales (ver feriados.New). Para considerar sólo los
+// fines de semana debe usarse FinesDeSemana.
+func New(c Calendario) *Cronograma {
+	if c == nil {
+		c = feriados.New()
+	}
+	return &Cronograma{calendario: c, fechas: map[clave]time.Time{}}
+}
+
+// Add registra que la obligación o del período p vence en la fecha f para
+// los CUIT terminados en cualquiera de los dígitos de terminaciones.
+//
+// Sólo se usa el año, mes y día de f; la fecha de vencimiento retornada por
+// DueDate es siempre a las 0 horas de Buenos Aires.
+func (c *Cronograma) Add(o Obligacion, p periodo.Periodo, terminaciones []uint, f time.Time) error {
+	if p.IsZero() {
+		return errors.Errorf("el período del vencimiento de %s es inválido", o)
+	}
+	for _, t := range terminaciones {
+		if t > 9 {
+			return errors.Errorf("la terminación de cuit %d es inválida", t)
+		}
+	}
+	f = time.Date(f.Year(), f.Month(), f.Day(), 0, 0, 0, 0, periodo.BuenosAires())
+	for _, t := range terminaciones {
+		c.fechas[clave{o, p, t}] = f
+	}
+	return nil
+}
+
+// Load agrega al cronograma los vencimientos leídos desde r en formato CSV.
+//
+// Cada registro debe tener cuatro columnas: la obligación, el período en
+// alguna de las formas aceptadas por periodo.Parse, las terminaciones de
+// CUIT (un dígito o un rango de dígitos como "0-1") y la fecha de
+// vencimiento con la forma YYYY-MM-DD. Se ignoran las líneas vacías, las que
+// comienzan con # y un primer registro de encabezado que comience con
+// "obligacion". Los registros no pueden ocupar más de una línea. Por
+// ejemplo:
+//
+//	obligacion,periodo,terminaciones,vencimiento
+//	IVA,202001,0-1,2020-02-18
+//	IVA,202001,2-3,2020-02-19
+//
+// En caso de error los vencimientos leídos antes del registro erróneo
+// quedan agregados.
+func (c *Cronograma) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	first := true
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		cr := csv.NewReader(strings.NewReader(text))
+		cr.FieldsPerRecord = 4
+		cr.TrimLeadingSpace = true
+		rec, err := cr.Read()
+		if err == nil && first && strings.EqualFold(rec[0], "obligacion") {
+			first = false
+			continue
+		}
+		first = false
+		if err == nil {
+			err = c.load(rec)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "línea %d", line)
+		}
+	}
+	return errors.Wrap(scanner.Err(), "leyendo vencimientos")
+}
+
+func (c *Cronograma) load(rec []string) error {
+	o := Obligacion(strings.ToUpper(strings.TrimSpace(rec[0])))
+	p, err := periodo.Parse(strings.TrimSpace(rec[1]))
+	if err != nil {
+		return err
+	}
+	ts, err := terminaciones(strings.TrimSpace(rec[2]))
+	if err != nil {
+		return err
+	}
+	f, err := time.Parse("2006-01-02", strings.TrimSpace(rec[3]))
+	if err != nil {
+		return errors.Errorf("formato de fecha de vencimiento incorrecto: %q", rec[3])
+	}
+	return c.Add(o, p, ts, f)
+}
+
+// terminaciones interpreta un dígito o un rango de dígitos "d-h".
+func terminaciones(s string) ([]uint, error) {
+	desde, hasta := s, s
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		desde, hasta = s[:i], s[i+1:]
+	}
+	d, err1 := strconv.ParseUint(desde, 10, 8)
+	h, err2 := strconv.ParseUint(hasta, 10, 8)
+	if err1 != nil || err2 != nil || d > h || h > 9 {
+		return nil, errors.Errorf("formato de terminaciones de cuit incorrecto: %q", s)
+	}
+	var ts []uint
+	for t := d; t <= h; t++ {
+		ts = append(ts, uint(t))
+	}
+	return ts, nil
+}
+
+// LoadFile es como Load pero lee los vencimientos desde el archivo path.
+func (c *Cronograma) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "abriendo archivo de vencimientos")
+	}
+	defer f.Close()
+	return errors.Wrap(c.Load(f), path)
+}
+
+// DueDate retorna la fecha de vencimiento de la obligación o del período p
+// para el CUIT c, trasladada al siguiente día hábil si no lo es.
+//
+// La terminación usada es el dígito verificador de c (ver cuit.Parts). La
+// fecha retornada es a las 0 horas de Buenos Aires.
+//
+// Retorna el error de cuit.Validate si c no es válido, un *NotFoundError si
+// el cronograma no tiene la fecha solicitada o un error si el calendario no
+// tiene días hábiles en el año siguiente a la fecha.
+func (c *Cronograma) DueDate(contribuyente uint64, p periodo.Periodo, o Obligacion) (time.Time, error) {
+	if err := cuit.Validate(contribuyente); err != nil {
+		return time.Time{}, err
+	}
+	_, _, ver := cuit.Parts(contribuyente)
+	f, ok := c.fechas[clave{o, p, uint(ver)}]
+	if !ok {
+		return time.Time{}, &NotFoundError{Obligacion: o, Periodo: p, Terminacion: uint(ver)}
+	}
+	return nextBusinessDay(c.calendario, f)
+}
+
+// DueDatePeriodo es como DueDate pero retorna la fecha de vencimiento como
+// un período diario.
+func (c *Cronograma) DueDatePeriodo(contribuyente uint64, p periodo.Periodo, o Obligacion) (periodo.Periodo, error) {
+	f, err := c.DueDate(contribuyente, p, o)
+	if err != nil {
+		return periodo.Periodo{}, err
+	}
+	return periodo.FromTime(f, periodo.Diario, periodo.BuenosAires())
+}
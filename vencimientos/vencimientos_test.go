@@ -0,0 +1,223 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vencimientos
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lalloni/afip/cuit"
+	"github.com/lalloni/afip/periodo"
+)
+
+func Example() {
+	c := New(nil)
+	if err := c.LoadFile("testdata/iva-2020.csv"); err != nil {
+		panic(err)
+	}
+	p, _ := periodo.NewMensual(2020, 1)
+	f, _ := c.DueDate(20242643772, p, IVA)
+	fmt.Println(f.Format("2006-01-02"))
+	// Output:
+	// 2020-02-19
+}
+
+// terminada retorna un cuit válido terminado en el dígito t.
+func terminada(t uint64) uint64 {
+	for id := uint64(24264377); ; id++ {
+		c := cuit.Compose(20, id, 0)
+		if v := cuit.Verifier(c); v == t {
+			if c = cuit.Compose(20, id, v); cuit.IsValid(c) {
+				return c
+			}
+		}
+	}
+}
+
+func cargado(t *testing.T, cal Calendario) *Cronograma {
+	c := New(cal)
+	for _, f := range []string{"testdata/iva-2020.csv", "testdata/ganancias-2019.csv"} {
+		if err := c.LoadFile(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return c
+}
+
+func TestDueDate(t *testing.T) {
+	calendarios := map[string]*Cronograma{
+		"feriados":      cargado(t, nil),
+		"FinesDeSemana": cargado(t, FinesDeSemana),
+	}
+	tests := []struct {
+		terminacion uint64
+		periodo     string
+		obligacion  Obligacion
+		want        string // con el calendario predeterminado
+		finde       string // con FinesDeSemana
+	}{
+		{0, "202001", IVA, "2020-02-18", "2020-02-18"},
+		{1, "202001", IVA, "2020-02-18", "2020-02-18"},
+		{5, "202001", IVA, "2020-02-20", "2020-02-20"},
+		{8, "202001", IVA, "2020-02-26", "2020-02-24"}, // sábado y Carnaval
+		{6, "202002", IVA, "2020-03-25", "2020-03-23"}, // sábado, puente y feriado
+		{9, "202002", IVA, "2020-03-25", "2020-03-23"}, // domingo, puente y feriado
+		{3, "2019", Ganancias, "2020-06-11", "2020-06-11"},
+		{7, "2019", Ganancias, "2020-06-16", "2020-06-15"}, // feriado trasladado
+	}
+	for name, c := range calendarios {
+		for _, test := range tests {
+			c, test, want := c, test, test.want
+			if name == "FinesDeSemana" {
+				want = test.finde
+			}
+			t.Run(fmt.Sprintf("%s %s %s %d", name, test.obligacion, test.periodo, test.terminacion), func(t *testing.T) {
+				p, err := periodo.Parse(test.periodo)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := c.DueDate(terminada(test.terminacion), p, test.obligacion)
+				if err != nil {
+					t.Fatalf("DueDate() unexpected error = %v", err)
+				}
+				if got.Format("2006-01-02") != want || got.Location() != periodo.BuenosAires() || got.Hour() != 0 {
+					t.Errorf("DueDate() = %v, want %v", got, want)
+				}
+				d, err := c.DueDatePeriodo(terminada(test.terminacion), p, test.obligacion)
+				if err != nil || d.Format(periodo.ISO) != want {
+					t.Errorf("DueDatePeriodo() = %v, %v, want %v", d, err, want)
+				}
+			})
+		}
+	}
+}
+
+type noHabiles []string
+
+func (f noHabiles) IsBusinessDay(t time.Time) bool {
+	for _, d := range f {
+		if t.Format("2006-01-02") == d {
+			return false
+		}
+	}
+	return FinesDeSemana.IsBusinessDay(t)
+}
+
+func TestDueDateCalendario(t *testing.T) {
+	c := New(noHabiles{"2020-02-24", "2020-02-25"})
+	if err := c.LoadFile("testdata/iva-2020.csv"); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := periodo.NewMensual(2020, 1)
+	got, err := c.DueDate(terminada(9), p, IVA)
+	if err != nil || got.Format("2006-01-02") != "2020-02-26" {
+		t.Errorf("DueDate() = %v, %v, want 2020-02-26", got, err)
+	}
+}
+
+type nuncaHabil struct{}
+
+func (nuncaHabil) IsBusinessDay(time.Time) bool { return false }
+
+func TestDueDateSinDiasHabiles(t *testing.T) {
+	c := New(nuncaHabil{})
+	if err := c.LoadFile("testdata/iva-2020.csv"); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := periodo.NewMensual(2020, 1)
+	_, err := c.DueDate(terminada(9), p, IVA)
+	if err == nil || err.Error() != "no hay días hábiles en los 366 días siguientes al 2020-02-22" {
+		t.Errorf("DueDate() error = %v", err)
+	}
+}
+
+func TestDueDateErrors(t *testing.T) {
+	c := cargado(t, nil)
+	p, _ := periodo.NewMensual(2020, 3)
+	_, err := c.DueDate(terminada(4), p, IVA)
+	if nf, ok := err.(*NotFoundError); !ok || nf.Terminacion != 4 || nf.Obligacion != IVA || !nf.Periodo.Equal(p) {
+		t.Errorf("DueDate() error = %#v, want *NotFoundError", err)
+	} else if err.Error() != "no hay vencimiento de IVA para el período 202003 y la terminación de cuit 4" {
+		t.Errorf("DueDate() error = %q", err)
+	}
+	p, _ = periodo.NewMensual(2020, 1)
+	if _, err := c.DueDate(terminada(4), p, SICOSS); err == nil {
+		t.Error("DueDate() expected error")
+	}
+	if _, err := c.DueDate(20242643773, p, IVA); err == nil || err.Error() != "el dígito verificador del cuit/cuil 20-24264377-3 es incorrecto (debería ser 2)" {
+		t.Errorf("DueDate() error = %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"vacío", "", ""},
+		{"sin encabezado", "iva,202001,0-9,2020-02-18\n", ""},
+		{"comentarios y espacios", "# x\n\nobligacion,periodo,terminaciones,vencimiento\n  IVA, 2020T1, 3, 2020-04-20\n", ""},
+		{"período inválido", "obligacion,periodo,terminaciones,vencimiento\nIVA,202013,0,2020-02-18\n", "línea 2: período mensual 202013 inválido: el mes 13 está fuera del rango [0,12]"},
+		{"terminaciones", "IVA,202001,5-2,2020-02-18\n", `línea 1: formato de terminaciones de cuit incorrecto: "5-2"`},
+		{"terminación", "IVA,202001,10,2020-02-18\n", `línea 1: formato de terminaciones de cuit incorrecto: "10"`},
+		{"fecha", "IVA,202001,1,18/02/2020\n", `línea 1: formato de fecha de vencimiento incorrecto: "18/02/2020"`},
+		{"columnas", "IVA,202001,1\n", "línea 1: record on line 1: wrong number of fields"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			err := New(nil).Load(strings.NewReader(test.data))
+			if test.wantErr == "" && err != nil {
+				t.Errorf("Load() unexpected error = %v", err)
+			}
+			if test.wantErr != "" && (err == nil || err.Error() != test.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+	if err := New(nil).LoadFile("testdata/inexistente.csv"); err == nil {
+		t.Error("LoadFile() expected error")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	c := New(nil)
+	p, _ := periodo.NewTrimestral(2020, 1)
+	if err := c.Add(SICOSS, p, []uint{0, 10}, time.Now()); err == nil {
+		t.Error("Add() expected error")
+	}
+	if err := c.Add(SICOSS, periodo.Periodo{}, []uint{0}, time.Now()); err == nil {
+		t.Error("Add() expected error")
+	}
+	f := time.Date(2020, 4, 20, 23, 30, 0, 0, time.UTC)
+	if err := c.Add(SICOSS, p, []uint{0}, f); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.DueDate(terminada(0), p, SICOSS)
+	if err != nil || got.Format(time.RFC3339) != "2020-04-20T00:00:00-03:00" {
+		t.Errorf("DueDate() = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,151 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Key identifica a un ticket de acceso en un Cache.
+type Key struct {
+	// CUIT es el CUIT del certificado del solicitante.
+	CUIT uint64
+	// Service es el servicio de negocio del ticket.
+	Service string
+	// Environment es el ambiente de WSAA que emitió el ticket.
+	Environment Environment
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("%s-%d-%s", k.Environment, k.CUIT, k.Service)
+}
+
+// Cache almacena tickets de acceso.
+//
+// Sus implementaciones deben poder usarse concurrentemente.
+type Cache interface {
+	// Get retorna el ticket almacenado con la clave k o nil si no hay
+	// ninguno.
+	Get(k Key) (*TA, error)
+	// Put almacena el ticket ta con la clave k reemplazando al anterior.
+	Put(k Key, ta *TA) error
+}
+
+type memoryCache struct {
+	mu  sync.Mutex
+	tas map[Key]*TA
+}
+
+// NewMemoryCache construye un Cache que almacena los tickets en memoria.
+func NewMemoryCache() Cache {
+	return &memoryCache{tas: map[Key]*TA{}}
+}
+
+func (c *memoryCache) Get(k Key) (*TA, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tas[k], nil
+}
+
+func (c *memoryCache) Put(k Key, ta *TA) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tas[k] = ta
+	return nil
+}
+
+type fileCache struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileCache construye un Cache que almacena cada ticket en un archivo
+// XML del directorio dir, que se crea si no existe.
+//
+// Los archivos se crean con permisos 0600 porque el token y la firma
+// permiten operar en nombre del solicitante. El nombre de cada archivo
+// incluye el servicio escapado como un segmento de URL, de modo que
+// siempre quede dentro de dir.
+func NewFileCache(dir string) Cache {
+	return &fileCache{dir: dir}
+}
+
+// name retorna el nombre de archivo de la clave k, con el servicio
+// escapado para que no pueda contener separadores de ruta.
+func (c *fileCache) name(k Key) string {
+	return fmt.Sprintf("%s-%d-%s", k.Environment, k.CUIT, url.PathEscape(k.Service))
+}
+
+func (c *fileCache) path(k Key) string {
+	return filepath.Join(c.dir, c.name(k)+".xml")
+}
+
+func (c *fileCache) Get(k Key) (*TA, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	bs, err := ioutil.ReadFile(c.path(k))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "leyendo ticket de acceso")
+	}
+	return ParseTA(bs)
+}
+
+func (c *fileCache) Put(k Key, ta *TA) error {
+	bs, err := ta.Marshal()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return errors.Wrap(err, "creando directorio de tickets de acceso")
+	}
+	// Se escribe en un archivo temporal y se renombra para que los lectores
+	// nunca vean un ticket incompleto.
+	f, err := ioutil.TempFile(c.dir, c.name(k)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "guardando ticket de acceso")
+	}
+	_, err = f.Write(bs)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), c.path(k))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return errors.Wrap(err, "guardando ticket de acceso")
+	}
+	return nil
+}
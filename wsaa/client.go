@@ -0,0 +1,265 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lalloni/afip/cuit"
+)
+
+// Environment es un ambiente de WSAA.
+type Environment uint8
+
+const (
+	// Homologacion es el ambiente de pruebas de AFIP.
+	Homologacion Environment = iota
+	// Produccion es el ambiente productivo de AFIP.
+	Produccion
+)
+
+func (e Environment) String() string {
+	switch e {
+	case Homologacion:
+		return "homologacion"
+	case Produccion:
+		return "produccion"
+	default:
+		return "Environment(" + strconv.Itoa(int(e)) + ")"
+	}
+}
+
+// URL retorna la dirección del servicio loginCms del ambiente.
+func (e Environment) URL() string {
+	switch e {
+	case Produccion:
+		return "https://wsaa.afip.gov.ar/ws/services/LoginCms"
+	default:
+		return "https://wsaahomo.afip.gov.ar/ws/services/LoginCms"
+	}
+}
+
+// DefaultMargin es el margen antes del vencimiento de un ticket de acceso a
+// partir del cual el Client solicita uno nuevo.
+const DefaultMargin = 5 * time.Minute
+
+// Client obtiene tickets de acceso de WSAA y los guarda en un Cache hasta
+// poco antes de su vencimiento, ya que WSAA rechaza nuevas solicitudes
+// mientras el ticket anterior sigue vigente.
+//
+// Puede usarse concurrentemente desde varias goroutines.
+type Client struct {
+	// Signer firma los tickets de requerimiento de acceso.
+	Signer *Signer
+	// Environment es el ambiente de WSAA a usar.
+	Environment Environment
+	// Endpoint reemplaza la dirección del ambiente si no está vacío.
+	Endpoint string
+	// HTTPClient es el cliente HTTP a usar. Si es nil se usa
+	// http.DefaultClient.
+	HTTPClient *http.Client
+	// Cache almacena los tickets obtenidos. Si es nil no se almacenan.
+	Cache Cache
+	// Margin es el margen antes del vencimiento a partir del cual se
+	// solicita un nuevo ticket. Si es 0 se usa DefaultMargin.
+	Margin time.Duration
+	// Now retorna el instante actual. Si es nil se usa time.Now.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	locks map[Key]*sync.Mutex
+}
+
+// NewClient construye un Client para el ambiente env que firma con signer y
+// guarda los tickets en memoria.
+func NewClient(signer *Signer, env Environment) *Client {
+	return &Client{Signer: signer, Environment: env, Cache: NewMemoryCache()}
+}
+
+// CertificateCUIT extrae el CUIT del atributo serialNumber del sujeto de un
+// certificado emitido por AFIP, que tiene la forma "CUIT 20242643772".
+func CertificateCUIT(cert *x509.Certificate) (uint64, error) {
+	sn := strings.TrimSpace(cert.Subject.SerialNumber)
+	if !strings.HasPrefix(strings.ToUpper(sn), "CUIT ") {
+		return 0, errors.Errorf("el certificado %q no tiene cuit", cert.Subject.String())
+	}
+	c, err := cuit.Parse(strings.TrimSpace(sn[5:]))
+	if err != nil {
+		return 0, err
+	}
+	return c, cuit.Validate(c)
+}
+
+func (c *Client) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Client) margin() time.Duration {
+	if c.Margin != 0 {
+		return c.Margin
+	}
+	return DefaultMargin
+}
+
+// Login retorna un ticket de acceso al servicio service, reutilizando el
+// almacenado en el Cache si sigue siendo válido.
+//
+// Los errores informados por WSAA se retornan como *Fault. Si WSAA informa
+// que ya existe un ticket vigente (ErrAlreadyAuthenticated) y el Cache tiene
+// uno que aún no venció, se retorna ese ticket.
+//
+// Los login concurrentes con el mismo servicio se serializan para que sólo
+// uno de ellos invoque a WSAA; los de distintos servicios no se demoran
+// entre sí.
+func (c *Client) Login(ctx context.Context, service string) (*TA, error) {
+	n, err := CertificateCUIT(c.Signer.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	k := Key{CUIT: n, Service: service, Environment: c.Environment}
+	l := c.lock(k)
+	l.Lock()
+	defer l.Unlock()
+	var cached *TA
+	if c.Cache != nil {
+		if cached, err = c.Cache.Get(k); err != nil {
+			return nil, err
+		}
+		if cached != nil && cached.ValidAt(c.now(), c.margin()) {
+			return cached, nil
+		}
+	}
+	ta, err := c.LoginCms(ctx, service)
+	if f, ok := err.(*Fault); ok && f.Code == CodeAlreadyAuthenticated && cached != nil && cached.ValidAt(c.now(), 0) {
+		return cached, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.Cache != nil {
+		if err := c.Cache.Put(k, ta); err != nil {
+			return nil, err
+		}
+	}
+	return ta, nil
+}
+
+// lock retorna el mutex que serializa los login con la clave k, de modo
+// que una invocación lenta a WSAA no demore a las de otras claves.
+func (c *Client) lock(k Key) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.locks == nil {
+		c.locks = map[Key]*sync.Mutex{}
+	}
+	l := c.locks[k]
+	if l == nil {
+		l = &sync.Mutex{}
+		c.locks[k] = l
+	}
+	return l
+}
+
+// LoginCms solicita a WSAA un nuevo ticket de acceso al servicio service
+// sin usar el Cache.
+func (c *Client) LoginCms(ctx context.Context, service string) (*TA, error) {
+	signed, err := c.Signer.SignTRA(NewTRA(service, c.now()))
+	if err != nil {
+		return nil, err
+	}
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` +
+		`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:wsaa="http://wsaa.view.sua.dvadac.desein.afip.gov">` +
+		`<soapenv:Header/><soapenv:Body><wsaa:loginCms><wsaa:in0>`)
+	if err := xml.EscapeText(&body, []byte(signed)); err != nil {
+		return nil, errors.Wrap(err, "codificando solicitud")
+	}
+	body.WriteString(`</wsaa:in0></wsaa:loginCms></soapenv:Body></soapenv:Envelope>`)
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = c.Environment.URL()
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, &body)
+	if err != nil {
+		return nil, errors.Wrap(err, "construyendo solicitud")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", `""`)
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "invocando loginCms")
+	}
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "leyendo respuesta de loginCms")
+	}
+	return parseResponse(res.StatusCode, data)
+}
+
+type envelope struct {
+	Body struct {
+		Fault *struct {
+			Code   string `xml:"faultcode"`
+			String string `xml:"faultstring"`
+		} `xml:"Fault"`
+		Response *struct {
+			Return string `xml:"loginCmsReturn"`
+		} `xml:"loginCmsResponse"`
+	} `xml:"Body"`
+}
+
+// parseResponse interpreta la respuesta SOAP de loginCms.
+func parseResponse(status int, data []byte) (*TA, error) {
+	var env envelope
+	if err := xml.Unmarshal(data, &env); err != nil {
+		return nil, errors.Errorf("respuesta de loginCms inválida (estado http %d)", status)
+	}
+	switch {
+	case env.Body.Fault != nil:
+		return nil, &Fault{Code: faultCode(env.Body.Fault.Code), Message: strings.TrimSpace(env.Body.Fault.String)}
+	case env.Body.Response != nil && status == http.StatusOK:
+		return ParseTA([]byte(env.Body.Response.Return))
+	default:
+		return nil, errors.Errorf("respuesta de loginCms inválida (estado http %d)", status)
+	}
+}
@@ -0,0 +1,399 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	stderrors "errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lalloni/afip/wsaa/internal/cms"
+	"github.com/lalloni/afip/wsaa/internal/testcert"
+)
+
+// fake es una implementación mínima de loginCms para las pruebas del
+// cliente.
+type fake struct {
+	t      *testing.T
+	roots  *x509.CertPool
+	mu     sync.Mutex
+	logins int
+	fault  string
+	status int
+	body   string
+	// Las solicitudes del servicio "lento" avisan por arrived y esperan a
+	// que se cierre release antes de ser atendidas.
+	arrived chan struct{}
+	release chan struct{}
+}
+
+func (f *fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bs, _ := ioutil.ReadAll(r.Body)
+	if f.release != nil && slow(bs) {
+		f.arrived <- struct{}{}
+		<-f.release
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logins++
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		fmt.Fprint(w, f.body)
+		return
+	}
+	if f.fault != "" {
+		w.Header().Set("Content-Type", "text/xml")
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><soapenv:Fault><faultcode xmlns:ns1="http://xml.apache.org/axis/">ns1:%s</faultcode><faultstring>falla inyectada</faultstring></soapenv:Fault></soapenv:Body></soapenv:Envelope>`, f.fault)
+		return
+	}
+	var req struct {
+		In0 string `xml:"Body>loginCms>in0"`
+	}
+	if err := xml.Unmarshal(bs, &req); err != nil {
+		f.t.Errorf("solicitud inválida: %v", err)
+	}
+	if r.Header.Get("SOAPAction") != `""` || r.Header.Get("Content-Type") != "text/xml; charset=utf-8" {
+		f.t.Errorf("encabezados inválidos: %v", r.Header)
+	}
+	der, err := base64.StdEncoding.DecodeString(req.In0)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	s, err := cms.Verify(der, f.roots, time.Now())
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	tra, err := ParseTRA(s.Content)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	ta := &TA{
+		Version:        "1.0",
+		Source:         "CN=wsaahomo, O=AFIP, C=AR, SERIALNUMBER=CUIT 33693450239",
+		Destination:    s.Certificate.Subject.String(),
+		UniqueID:       uint32(f.logins),
+		GenerationTime: tra.GenerationTime,
+		ExpirationTime: tra.GenerationTime.Add(12 * time.Hour),
+		Token:          fmt.Sprintf("token-%s-%d", tra.Service, f.logins),
+		Sign:           "firma",
+	}
+	tabs, _ := ta.Marshal()
+	w.Header().Set("Content-Type", "text/xml")
+	fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/"><soapenv:Body><loginCmsResponse xmlns="http://wsaa.view.sua.dvadac.desein.afip.gov"><loginCmsReturn>`)
+	_ = xml.EscapeText(w, tabs)
+	fmt.Fprint(w, `</loginCmsReturn></loginCmsResponse></soapenv:Body></soapenv:Envelope>`)
+}
+
+// slow informa si la solicitud es del servicio "lento", cuyo TRA viaja sin
+// cifrar dentro del CMS.
+func slow(body []byte) bool {
+	var req struct {
+		In0 string `xml:"Body>loginCms>in0"`
+	}
+	if xml.Unmarshal(body, &req) != nil {
+		return false
+	}
+	der, err := base64.StdEncoding.DecodeString(req.In0)
+	return err == nil && bytes.Contains(der, []byte("<service>lento</service>"))
+}
+
+func setup(t *testing.T) (*fake, *Client, func()) {
+	ca, leaf := pairs(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	f := &fake{t: t, roots: roots}
+	srv := httptest.NewServer(f)
+	signer, err := NewSigner(leaf.Certificate, leaf.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClient(signer, Homologacion)
+	c.Endpoint = srv.URL
+	return f, c, srv.Close
+}
+
+func TestClientLogin(t *testing.T) {
+	f, c, done := setup(t)
+	defer done()
+	now := time.Now()
+	c.Now = func() time.Time { return now }
+	ta, err := c.Login(context.Background(), "wsfe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ta.Token != "token-wsfe-1" || ta.Sign != "firma" || ta.Destination != "SERIALNUMBER=CUIT 20242643772,CN=prueba" {
+		t.Errorf("Login() = %+v", ta)
+	}
+	// Se reutiliza el ticket almacenado.
+	if ta, err = c.Login(context.Background(), "wsfe"); err != nil || ta.Token != "token-wsfe-1" || f.logins != 1 {
+		t.Errorf("Login() = %v, %v con %d logins", ta, err, f.logins)
+	}
+	// Otro servicio requiere otro ticket.
+	if ta, err = c.Login(context.Background(), "ws_sr_padron_a5"); err != nil || ta.Token != "token-ws_sr_padron_a5-2" {
+		t.Errorf("Login() = %v, %v", ta, err)
+	}
+	// Cerca del vencimiento se solicita uno nuevo.
+	now = now.Add(12*time.Hour - 10*time.Minute - DefaultMargin)
+	if ta, err = c.Login(context.Background(), "wsfe"); err != nil || ta.Token != "token-wsfe-3" {
+		t.Errorf("Login() = %v, %v", ta, err)
+	}
+}
+
+func TestClientConcurrentServices(t *testing.T) {
+	f, c, done := setup(t)
+	defer done()
+	f.arrived, f.release = make(chan struct{}), make(chan struct{})
+	if _, err := c.Login(context.Background(), "wsfe"); err != nil {
+		t.Fatal(err)
+	}
+	errs := make(chan error)
+	go func() {
+		_, err := c.Login(context.Background(), "lento")
+		errs <- err
+	}()
+	<-f.arrived
+	// Mientras el login de "lento" está en curso pueden obtenerse tickets
+	// de otros servicios, almacenados o no.
+	ok := make(chan error)
+	go func() {
+		if _, err := c.Login(context.Background(), "wsfe"); err != nil {
+			ok <- err
+			return
+		}
+		_, err := c.Login(context.Background(), "wsmtxca")
+		ok <- err
+	}()
+	select {
+	case err := <-ok:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Login() bloqueado por el login de otro servicio")
+	}
+	close(f.release)
+	if err := <-errs; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestClientAlreadyAuthenticated(t *testing.T) {
+	f, c, done := setup(t)
+	defer done()
+	now := time.Now()
+	c.Now = func() time.Time { return now }
+	first, err := c.Login(context.Background(), "wsfe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Dentro del margen WSAA todavía rechaza el login pero el ticket
+	// almacenado sigue siendo usable.
+	f.fault = CodeAlreadyAuthenticated
+	now = now.Add(12*time.Hour - 10*time.Minute - time.Minute)
+	ta, err := c.Login(context.Background(), "wsfe")
+	if err != nil || ta != first {
+		t.Errorf("Login() = %v, %v, want %v", ta, err, first)
+	}
+	// Sin ticket almacenado el error se informa.
+	_, err = c.Login(context.Background(), "wsmtxca")
+	if !stderrors.Is(err, ErrAlreadyAuthenticated) {
+		t.Errorf("Login() error = %v, want %v", err, ErrAlreadyAuthenticated)
+	}
+	if err.Error() != "error de wsaa coe.alreadyAuthenticated: falla inyectada" {
+		t.Errorf("Login() error = %q", err)
+	}
+	if stderrors.Is(err, ErrNotAuthorized) {
+		t.Error("errors.Is() no debe coincidir con otro código")
+	}
+}
+
+func TestClientFaults(t *testing.T) {
+	f, c, done := setup(t)
+	defer done()
+	for _, code := range []string{CodeCMSCertUntrusted, CodeXMLExpirationExpired, CodeNotAuthorized, CodeWSNNotFound} {
+		f.fault = code
+		_, err := c.LoginCms(context.Background(), "wsfe")
+		var fault *Fault
+		if !stderrors.As(err, &fault) || fault.Code != code || fault.Message != "falla inyectada" {
+			t.Errorf("LoginCms() error = %#v, want code %q", err, code)
+		}
+	}
+	f.fault = ""
+	f.status, f.body = http.StatusBadGateway, "<html>bad gateway</html>"
+	if _, err := c.LoginCms(context.Background(), "wsfe"); err == nil || err.Error() != "respuesta de loginCms inválida (estado http 502)" {
+		t.Errorf("LoginCms() error = %v", err)
+	}
+	f.status, f.body = http.StatusOK, "no es xml"
+	if _, err := c.LoginCms(context.Background(), "wsfe"); err == nil {
+		t.Error("LoginCms() expected error")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.LoginCms(ctx, "wsfe"); err == nil {
+		t.Error("LoginCms() con contexto cancelado expected error")
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wsaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	f, c, done := setup(t)
+	defer done()
+	c.Cache = NewFileCache(filepath.Join(dir, "tickets"))
+	ta, err := c.Login(context.Background(), "wsfe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "tickets", "homologacion-20242643772-wsfe.xml")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("permisos = %v, want 0600", info.Mode().Perm())
+	}
+	// Otro cliente con el mismo directorio reutiliza el ticket.
+	other := NewClient(c.Signer, Homologacion)
+	other.Endpoint, other.Cache = c.Endpoint, NewFileCache(filepath.Join(dir, "tickets"))
+	got, err := other.Login(context.Background(), "wsfe")
+	if err != nil || got.Token != ta.Token || !got.ExpirationTime.Equal(ta.ExpirationTime) || f.logins != 1 {
+		t.Errorf("Login() = %+v, %v con %d logins", got, err, f.logins)
+	}
+	if ta, err := other.Cache.Get(Key{CUIT: 20242643772, Service: "otro"}); ta != nil || err != nil {
+		t.Errorf("Get() = %v, %v, want nil", ta, err)
+	}
+	if err := ioutil.WriteFile(path, []byte("basura"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Cache.Get(Key{CUIT: 20242643772, Service: "wsfe"}); err == nil {
+		t.Error("Get() expected error")
+	}
+}
+
+func TestFileCacheServiceEscaping(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wsaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache := NewFileCache(filepath.Join(dir, "tickets"))
+	now := time.Now().Truncate(time.Second)
+	ta := &TA{Version: "1.0", GenerationTime: now, ExpirationTime: now.Add(time.Hour), Token: "t", Sign: "s"}
+	bs, err := ta.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Un ticket fuera del directorio no debe poder leerse.
+	outside := Key{CUIT: 20242643772, Service: "../../x/y"}
+	if err := os.MkdirAll(filepath.Join(dir, "x"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "x", "y.xml"), bs, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := cache.Get(outside); got != nil || err != nil {
+		t.Errorf("Get(%v) = %v, %v, want nil", outside, got, err)
+	}
+	// Los servicios con separadores se almacenan dentro del directorio.
+	for _, service := range []string{"../../x/y", "a/b", `a\b`, "*"} {
+		k := Key{CUIT: 20242643772, Service: service}
+		if err := cache.Put(k, ta); err != nil {
+			t.Errorf("Put(%v) error = %v", k, err)
+			continue
+		}
+		if got, err := cache.Get(k); err != nil || got == nil || got.Token != "t" {
+			t.Errorf("Get(%v) = %v, %v", k, got, err)
+		}
+	}
+	files, err := ioutil.ReadDir(filepath.Join(dir, "tickets"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 4 {
+		t.Errorf("archivos = %d, want 4", len(files))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "x", "y.xml")); err != nil {
+		t.Errorf("el ticket externo fue modificado: %v", err)
+	}
+}
+
+func TestCertificateCUIT(t *testing.T) {
+	_, leaf := pairs(t)
+	if n, err := CertificateCUIT(leaf.Certificate); err != nil || n != 20242643772 {
+		t.Errorf("CertificateCUIT() = %v, %v", n, err)
+	}
+	ca, _ := testcert.CA("sin cuit")
+	if _, err := CertificateCUIT(ca.Certificate); err == nil {
+		t.Error("CertificateCUIT() expected error")
+	}
+	bad, _ := testcert.Leaf(ca, "malo", "20242643773", time.Now(), time.Now().Add(time.Hour))
+	if _, err := CertificateCUIT(bad.Certificate); err == nil {
+		t.Error("CertificateCUIT() expected error")
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	if Homologacion.String() != "homologacion" || Produccion.String() != "produccion" || Environment(5).String() != "Environment(5)" {
+		t.Error("Environment.String() inesperado")
+	}
+	if Homologacion.URL() != "https://wsaahomo.afip.gov.ar/ws/services/LoginCms" || Produccion.URL() != "https://wsaa.afip.gov.ar/ws/services/LoginCms" {
+		t.Error("Environment.URL() inesperado")
+	}
+}
+
+func TestParseTA(t *testing.T) {
+	now := time.Date(2020, 3, 16, 12, 0, 0, 0, time.UTC)
+	ta := &TA{Version: "1.0", GenerationTime: now, ExpirationTime: now.Add(time.Hour), Token: "t", Sign: "s"}
+	bs, err := ta.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseTA(bs)
+	if err != nil || got.Token != "t" || !got.ExpirationTime.Equal(ta.ExpirationTime) {
+		t.Errorf("ParseTA() = %+v, %v", got, err)
+	}
+	if !got.ValidAt(now, 0) || got.ValidAt(now.Add(-time.Second), 0) || got.ValidAt(now.Add(55*time.Minute), 5*time.Minute) {
+		t.Error("ValidAt() inesperado")
+	}
+	for _, data := range []string{"basura", "<loginTicketResponse/>", "<loginTicketResponse><credentials><token>t</token><sign>s</sign></credentials></loginTicketResponse>"} {
+		if _, err := ParseTA([]byte(data)); err == nil {
+			t.Errorf("ParseTA(%q) expected error", data)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package wsaa permite autenticarse ante el Web Service de Autenticación y
+// Autorización (WSAA) de AFIP.
+//
+// Para obtener un ticket de acceso el cliente debe construir un ticket de
+// requerimiento de acceso (TRA), firmarlo como un mensaje CMS SignedData con
+// el certificado emitido por AFIP y enviarlo codificado en base64 a la
+// operación loginCms:
+//
+//	signer, err := wsaa.LoadSigner("cert.pem", "key.pem")
+//	...
+//	cms, err := signer.SignTRA(wsaa.NewTRA("wsfe", time.Now()))
+//	...
+//
+// Client resuelve todo el circuito y conserva los tickets obtenidos hasta
+// poco antes de su vencimiento, ya que WSAA rechaza un nuevo login mientras
+// exista un ticket vigente para el mismo certificado y servicio:
+//
+//	c := wsaa.NewClient(signer, wsaa.Homologacion)
+//	c.Cache = wsaa.NewFileCache("/var/cache/afip")
+//	ta, err := c.Login(ctx, "wsfe")
+//	...
+package wsaa
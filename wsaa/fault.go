@@ -0,0 +1,89 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import "strings"
+
+// Códigos de los errores informados por WSAA.
+const (
+	CodeCMSBad                = "cms.bad"
+	CodeCMSSignInvalid        = "cms.sign.invalid"
+	CodeCMSCertExpired        = "cms.cert.expired"
+	CodeCMSCertInvalid        = "cms.cert.invalid"
+	CodeCMSCertUntrusted      = "cms.cert.untrusted"
+	CodeXMLBad                = "xml.bad"
+	CodeXMLSourceInvalid      = "xml.source.invalid"
+	CodeXMLDestinationInvalid = "xml.destination.invalid"
+	CodeXMLVersionUnsupported = "xml.version.notSupported"
+	CodeXMLGenerationInvalid  = "xml.generationTime.invalid"
+	CodeXMLExpirationExpired  = "xml.expirationTime.expired"
+	CodeXMLExpirationInvalid  = "xml.expirationTime.invalid"
+	CodeWSNUnavailable        = "wsn.unavailable"
+	CodeWSNNotFound           = "wsn.notFound"
+	CodeWSAAUnavailable       = "wsaa.unavailable"
+	CodeWSAAInternalError     = "wsaa.internalError"
+	CodeNotAuthorized         = "coe.notAuthorized"
+	CodeAlreadyAuthenticated  = "coe.alreadyAuthenticated"
+)
+
+// Errores más comunes informados por WSAA, para comparar con errors.Is.
+var (
+	ErrAlreadyAuthenticated = &Fault{Code: CodeAlreadyAuthenticated}
+	ErrNotAuthorized        = &Fault{Code: CodeNotAuthorized}
+	ErrCertExpired          = &Fault{Code: CodeCMSCertExpired}
+	ErrCertUntrusted        = &Fault{Code: CodeCMSCertUntrusted}
+	ErrExpired              = &Fault{Code: CodeXMLExpirationExpired}
+	ErrServiceNotFound      = &Fault{Code: CodeWSNNotFound}
+)
+
+// Fault es un error informado por WSAA como SOAP fault.
+type Fault struct {
+	// Code es el código del error sin prefijo de espacio de nombres, por
+	// ejemplo "coe.alreadyAuthenticated".
+	Code string
+	// Message es la descripción del error informada por WSAA.
+	Message string
+}
+
+func (f *Fault) Error() string {
+	if f.Message == "" {
+		return "error de wsaa " + f.Code
+	}
+	return "error de wsaa " + f.Code + ": " + f.Message
+}
+
+// Is informa si target es un *Fault con el mismo código que f, de modo que
+// errors.Is(err, ErrAlreadyAuthenticated) funcione sin importar el mensaje.
+func (f *Fault) Is(target error) bool {
+	t, ok := target.(*Fault)
+	return ok && t.Code == f.Code
+}
+
+// faultCode quita el prefijo de espacio de nombres de un faultcode SOAP.
+func faultCode(code string) string {
+	code = strings.TrimSpace(code)
+	if i := strings.LastIndexByte(code, ':'); i >= 0 {
+		return code[i+1:]
+	}
+	return code
+}
@@ -0,0 +1,334 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package cms implementa la firma y verificación de mensajes CMS (RFC 5652)
+// SignedData con contenido encapsulado y un único firmante RSA, que es la
+// forma en que WSAA recibe los tickets de requerimiento de acceso.
+package cms
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"sort"
+	"time"
+
+	// Registra las funciones de hash soportadas.
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	oidData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidRSA           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidSHA1WithRSA   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 5}
+	oidSHA256WithRSA = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11}
+	oidSHA1          = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256        = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+)
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type encapsulatedContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+type signerInfo struct {
+	Version            int
+	SID                issuerAndSerialNumber
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+}
+
+type issuerAndSerialNumber struct {
+	Issuer asn1.RawValue
+	Serial *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+// digestOID retorna el identificador del algoritmo de hash h.
+func digestOID(h crypto.Hash) (asn1.ObjectIdentifier, error) {
+	switch h {
+	case crypto.SHA1:
+		return oidSHA1, nil
+	case crypto.SHA256:
+		return oidSHA256, nil
+	default:
+		return nil, errors.Errorf("algoritmo de hash no soportado: %v", h)
+	}
+}
+
+// digestHash es la inversa de digestOID.
+func digestHash(oid asn1.ObjectIdentifier) (crypto.Hash, error) {
+	switch {
+	case oid.Equal(oidSHA1):
+		return crypto.SHA1, nil
+	case oid.Equal(oidSHA256):
+		return crypto.SHA256, nil
+	default:
+		return 0, errors.Errorf("algoritmo de hash no soportado: %v", oid)
+	}
+}
+
+func digest(h crypto.Hash, data []byte) []byte {
+	w := h.New()
+	_, _ = w.Write(data)
+	return w.Sum(nil)
+}
+
+// set retorna la codificación DER del conjunto de los elementos ya
+// codificados es.
+func set(es [][]byte) []byte {
+	sort.Slice(es, func(i, j int) bool { return bytes.Compare(es[i], es[j]) < 0 })
+	bs, _ := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: bytes.Join(es, nil)})
+	return bs
+}
+
+func marshalAttribute(oid asn1.ObjectIdentifier, value interface{}) ([]byte, error) {
+	v, err := asn1.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(attribute{Type: oid, Values: asn1.RawValue{FullBytes: set([][]byte{v})}})
+}
+
+// Sign retorna la codificación DER del mensaje CMS SignedData que encapsula
+// content firmado por key con el hash h en el instante now, incluyendo el
+// certificado cert del firmante.
+//
+// La clave pública de key debe ser RSA.
+func Sign(content []byte, cert *x509.Certificate, key crypto.Signer, h crypto.Hash, now time.Time) ([]byte, error) {
+	doid, err := digestOID(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := key.Public().(*rsa.PublicKey); !ok {
+		return nil, errors.Errorf("tipo de clave no soportado: %T", key.Public())
+	}
+	var attrs [][]byte
+	for _, a := range []struct {
+		oid   asn1.ObjectIdentifier
+		value interface{}
+	}{
+		{oidContentType, oidData},
+		{oidSigningTime, now.UTC()},
+		{oidMessageDigest, digest(h, content)},
+	} {
+		bs, err := marshalAttribute(a.oid, a.value)
+		if err != nil {
+			return nil, errors.Wrap(err, "codificando atributos firmados")
+		}
+		attrs = append(attrs, bs)
+	}
+	signed := set(attrs)
+	signature, err := key.Sign(rand.Reader, digest(h, signed), h)
+	if err != nil {
+		return nil, errors.Wrap(err, "firmando")
+	}
+	// Los atributos firmados se codifican con la etiqueta [0] en lugar de
+	// la de SET usada para calcular la firma.
+	var rv asn1.RawValue
+	if _, err := asn1.Unmarshal(signed, &rv); err != nil {
+		return nil, errors.Wrap(err, "codificando atributos firmados")
+	}
+	algorithm := pkix.AlgorithmIdentifier{Algorithm: doid, Parameters: asn1.NullRawValue}
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{algorithm},
+		EncapContentInfo: encapsulatedContentInfo{ContentType: oidData, Content: content},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: cert.Raw},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			SID: issuerAndSerialNumber{
+				Issuer: asn1.RawValue{FullBytes: cert.RawIssuer},
+				Serial: cert.SerialNumber,
+			},
+			DigestAlgorithm:    algorithm,
+			SignedAttrs:        asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: rv.Bytes},
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidRSA, Parameters: asn1.NullRawValue},
+			Signature:          signature,
+		}},
+	}
+	inner, err := asn1.Marshal(sd)
+	if err != nil {
+		return nil, errors.Wrap(err, "codificando mensaje cms")
+	}
+	bs, err := asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: inner},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "codificando mensaje cms")
+	}
+	return bs, nil
+}
+
+// Signed es el resultado de verificar un mensaje CMS SignedData.
+type Signed struct {
+	// Content es el contenido encapsulado.
+	Content []byte
+	// Certificate es el certificado del firmante.
+	Certificate *x509.Certificate
+	// SigningTime es el instante de la firma o el instante cero si el
+	// mensaje no lo incluye.
+	SigningTime time.Time
+	// Hash es el algoritmo de hash usado en la firma.
+	Hash crypto.Hash
+}
+
+// Parse decodifica el mensaje CMS SignedData der y verifica que su firma
+// corresponda al contenido y al certificado incluido, sin verificar la
+// validez de ese certificado.
+func Parse(der []byte) (*Signed, error) {
+	var ci contentInfo
+	if rest, err := asn1.Unmarshal(der, &ci); err != nil || len(rest) > 0 || !ci.ContentType.Equal(oidSignedData) {
+		return nil, errors.New("el mensaje no es un cms signed data")
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, errors.Wrap(err, "decodificando cms signed data")
+	}
+	if !sd.EncapContentInfo.ContentType.Equal(oidData) || sd.EncapContentInfo.Content == nil {
+		return nil, errors.New("el mensaje no tiene contenido encapsulado")
+	}
+	if len(sd.SignerInfos) != 1 {
+		return nil, errors.Errorf("el mensaje tiene %d firmantes y se esperaba 1", len(sd.SignerInfos))
+	}
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "decodificando certificados")
+	}
+	si := sd.SignerInfos[0]
+	var cert *x509.Certificate
+	for _, c := range certs {
+		if bytes.Equal(c.RawIssuer, si.SID.Issuer.FullBytes) && c.SerialNumber.Cmp(si.SID.Serial) == 0 {
+			cert = c
+		}
+	}
+	if cert == nil {
+		return nil, errors.New("el mensaje no incluye el certificado del firmante")
+	}
+	h, err := digestHash(si.DigestAlgorithm.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	res := &Signed{Content: sd.EncapContentInfo.Content, Certificate: cert, Hash: h}
+	signed := si.SignedAttrs.FullBytes
+	if len(signed) > 0 {
+		// La firma se calcula sobre los atributos codificados como SET.
+		signed, _ = asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: si.SignedAttrs.Bytes})
+		if err := res.attributes(si.SignedAttrs.Bytes, h); err != nil {
+			return nil, err
+		}
+	} else {
+		signed = res.Content
+	}
+	if err := verify(cert, si.SignatureAlgorithm.Algorithm, h, signed, si.Signature); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// attributes extrae el instante de firma de los atributos firmados y
+// verifica que el hash del contenido sea el incluido en ellos.
+func (s *Signed) attributes(data []byte, h crypto.Hash) error {
+	var md []byte
+	for len(data) > 0 {
+		var a attribute
+		var err error
+		if data, err = asn1.Unmarshal(data, &a); err != nil {
+			return errors.Wrap(err, "decodificando atributos firmados")
+		}
+		switch {
+		case a.Type.Equal(oidMessageDigest):
+			_, err = asn1.Unmarshal(a.Values.Bytes, &md)
+		case a.Type.Equal(oidSigningTime):
+			_, err = asn1.Unmarshal(a.Values.Bytes, &s.SigningTime)
+		}
+		if err != nil {
+			return errors.Wrap(err, "decodificando atributos firmados")
+		}
+	}
+	if !bytes.Equal(md, digest(h, s.Content)) {
+		return errors.New("el hash del contenido no coincide con el firmado")
+	}
+	return nil
+}
+
+func verify(cert *x509.Certificate, alg asn1.ObjectIdentifier, h crypto.Hash, signed, signature []byte) error {
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok || !(alg.Equal(oidRSA) || alg.Equal(oidSHA1WithRSA) && h == crypto.SHA1 || alg.Equal(oidSHA256WithRSA) && h == crypto.SHA256) {
+		return errors.Errorf("algoritmo de firma no soportado: %v", alg)
+	}
+	if err := rsa.VerifyPKCS1v15(pub, h, digest(h, signed), signature); err != nil {
+		return errors.New("la firma del mensaje es inválida")
+	}
+	return nil
+}
+
+// Verify es como Parse pero además verifica que el certificado del firmante
+// sea válido en el instante now según las autoridades de certificación
+// roots.
+func Verify(der []byte, roots *x509.CertPool, now time.Time) (*Signed, error) {
+	s, err := Parse(der)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.Certificate.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "verificando certificado del firmante")
+	}
+	return s, nil
+}
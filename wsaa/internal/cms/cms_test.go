@@ -0,0 +1,135 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/lalloni/afip/wsaa/internal/testcert"
+)
+
+func pairs(t *testing.T) (ca, leaf *testcert.Pair) {
+	t.Helper()
+	ca, err := testcert.CA("AC de prueba")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err = testcert.Leaf(ca, "prueba", "20242643772", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca, leaf
+}
+
+func TestSignParse(t *testing.T) {
+	ca, leaf := pairs(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	content := []byte("<loginTicketRequest/>")
+	now := time.Date(2020, 3, 16, 12, 0, 0, 0, time.UTC)
+	for _, h := range []crypto.Hash{crypto.SHA1, crypto.SHA256} {
+		der, err := Sign(content, leaf.Certificate, leaf.Key, h, now)
+		if err != nil {
+			t.Fatalf("Sign(%v) unexpected error = %v", h, err)
+		}
+		s, err := Verify(der, roots, time.Now())
+		if err != nil {
+			t.Fatalf("Verify(%v) unexpected error = %v", h, err)
+		}
+		if string(s.Content) != string(content) || !s.Certificate.Equal(leaf.Certificate) || !s.SigningTime.Equal(now) || s.Hash != h {
+			t.Errorf("Verify(%v) = %+v", h, s)
+		}
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	ca, leaf := pairs(t)
+	other, _ := pairs(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	der, err := Sign([]byte("contenido"), leaf.Certificate, leaf.Key, crypto.SHA256, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRoots := x509.NewCertPool()
+	otherRoots.AddCert(other.Certificate)
+	if _, err := Verify(der, otherRoots, time.Now()); err == nil {
+		t.Error("Verify() con otra autoridad expected error")
+	}
+	if _, err := Verify(der, roots, time.Now().Add(48*time.Hour)); err == nil {
+		t.Error("Verify() con certificado vencido expected error")
+	}
+	if _, err := Parse(der[:len(der)-1]); err == nil {
+		t.Error("Parse() truncado expected error")
+	}
+	if _, err := Parse([]byte("basura")); err == nil || err.Error() != "el mensaje no es un cms signed data" {
+		t.Errorf("Parse() error = %v", err)
+	}
+	// Alterar el contenido invalida el hash firmado.
+	i := indexOf(der, []byte("contenido"))
+	tampered := append([]byte{}, der...)
+	tampered[i] = 'C'
+	if _, err := Parse(tampered); err == nil || err.Error() != "el hash del contenido no coincide con el firmado" {
+		t.Errorf("Parse() error = %v", err)
+	}
+	// Alterar la firma la invalida.
+	tampered = append([]byte{}, der...)
+	tampered[len(tampered)-1] ^= 0xff
+	if _, err := Parse(tampered); err == nil || err.Error() != "la firma del mensaje es inválida" {
+		t.Errorf("Parse() error = %v", err)
+	}
+	// Firmar con otra clave.
+	bad, err := Sign([]byte("contenido"), leaf.Certificate, other.Key, crypto.SHA256, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(bad); err == nil {
+		t.Error("Parse() firmado con otra clave expected error")
+	}
+}
+
+func indexOf(b, sub []byte) int {
+	for i := range b {
+		if string(b[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestSignErrors(t *testing.T) {
+	_, leaf := pairs(t)
+	if _, err := Sign(nil, leaf.Certificate, leaf.Key, crypto.MD5, time.Now()); err == nil {
+		t.Error("Sign(MD5) expected error")
+	}
+	ec, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if _, err := Sign(nil, leaf.Certificate, ec, crypto.SHA256, time.Now()); err == nil {
+		t.Error("Sign(ecdsa) expected error")
+	}
+}
@@ -0,0 +1,100 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package testcert genera certificados y claves RSA para las pruebas.
+package testcert
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Pair es un certificado con su clave privada.
+type Pair struct {
+	Certificate *x509.Certificate
+	Key         *rsa.PrivateKey
+}
+
+// CertPEM retorna el certificado codificado en PEM.
+func (p *Pair) CertPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.Certificate.Raw})
+}
+
+// KeyPEM retorna la clave privada codificada en PEM con formato PKCS#1.
+func (p *Pair) KeyPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(p.Key)})
+}
+
+var serial int64
+
+// CA genera un certificado autofirmado de autoridad de certificación.
+func CA(name string) (*Pair, error) {
+	return create(nil, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name, Organization: []string{"AFIP"}, Country: []string{"AR"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	})
+}
+
+// Leaf genera un certificado de computador firmado por ca con la forma de
+// los emitidos por AFIP (CN con el alias y serialNumber con el CUIT) válido
+// entre notBefore y notAfter.
+func Leaf(ca *Pair, alias string, cuit string, notBefore, notAfter time.Time) (*Pair, error) {
+	return create(ca, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: alias, SerialNumber: "CUIT " + cuit},
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+		KeyUsage:  x509.KeyUsageDigitalSignature,
+	})
+}
+
+func create(parent *Pair, template *x509.Certificate) (*Pair, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "generando clave")
+	}
+	template.SerialNumber = big.NewInt(atomic.AddInt64(&serial, 1))
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.Certificate, parent.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	if err != nil {
+		return nil, errors.Wrap(err, "generando certificado")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.Wrap(err, "decodificando certificado")
+	}
+	return &Pair{Certificate: cert, Key: key}, nil
+}
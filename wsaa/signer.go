@@ -0,0 +1,142 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lalloni/afip/wsaa/internal/cms"
+)
+
+// Signer firma tickets de requerimiento de acceso con un certificado X.509
+// y su clave privada RSA.
+type Signer struct {
+	// Certificate es el certificado del solicitante emitido por AFIP.
+	Certificate *x509.Certificate
+	// Key es la clave privada correspondiente a Certificate.
+	Key crypto.Signer
+	// Hash es el algoritmo de hash usado en la firma. Si es 0 se usa
+	// SHA-256; también se admite SHA-1.
+	Hash crypto.Hash
+	// Now retorna el instante que se registra como momento de la firma. Si
+	// es nil se usa time.Now.
+	Now func() time.Time
+}
+
+// NewSigner construye un Signer verificando que key sea una clave RSA
+// correspondiente a la clave pública de cert.
+func NewSigner(cert *x509.Certificate, key crypto.Signer) (*Signer, error) {
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("la clave pública del certificado no es rsa sino %T", cert.PublicKey)
+	}
+	if kpub, ok := key.Public().(*rsa.PublicKey); !ok || kpub.N.Cmp(pub.N) != 0 || kpub.E != pub.E {
+		return nil, errors.New("la clave privada no corresponde al certificado")
+	}
+	return &Signer{Certificate: cert, Key: key}, nil
+}
+
+// ParseSigner es como NewSigner pero decodifica el certificado y la clave
+// privada desde sus representaciones PEM. La clave puede tener formato
+// PKCS#1 ("RSA PRIVATE KEY") o PKCS#8 ("PRIVATE KEY").
+func ParseSigner(certPEM, keyPEM []byte) (*Signer, error) {
+	b, _ := pem.Decode(certPEM)
+	if b == nil || b.Type != "CERTIFICATE" {
+		return nil, errors.New("no se encontró un certificado en formato pem")
+	}
+	cert, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "decodificando certificado")
+	}
+	b, _ = pem.Decode(keyPEM)
+	if b == nil {
+		return nil, errors.New("no se encontró una clave privada en formato pem")
+	}
+	var key interface{}
+	switch b.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(b.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(b.Bytes)
+	default:
+		return nil, errors.Errorf("tipo de clave privada pem no soportado: %q", b.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "decodificando clave privada")
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("tipo de clave privada no soportado: %T", key)
+	}
+	return NewSigner(cert, signer)
+}
+
+// LoadSigner es como ParseSigner pero lee el certificado y la clave
+// privada desde los archivos certFile y keyFile.
+func LoadSigner(certFile, keyFile string) (*Signer, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "leyendo certificado")
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "leyendo clave privada")
+	}
+	return ParseSigner(certPEM, keyPEM)
+}
+
+// Sign retorna la codificación DER del mensaje CMS SignedData que
+// encapsula content firmado con la clave de s e incluye su certificado.
+func (s *Signer) Sign(content []byte) ([]byte, error) {
+	h := s.Hash
+	if h == 0 {
+		h = crypto.SHA256
+	}
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	return cms.Sign(content, s.Certificate, s.Key, h, now())
+}
+
+// SignTRA firma el ticket t y retorna el mensaje CMS codificado en base64,
+// listo para ser enviado a la operación loginCms.
+func (s *Signer) SignTRA(t *TRA) (string, error) {
+	xml, err := t.Marshal()
+	if err != nil {
+		return "", err
+	}
+	der, err := s.Sign(xml)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
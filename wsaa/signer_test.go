@@ -0,0 +1,149 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lalloni/afip/wsaa/internal/cms"
+	"github.com/lalloni/afip/wsaa/internal/testcert"
+)
+
+func pairs(t *testing.T) (ca, leaf *testcert.Pair) {
+	t.Helper()
+	ca, err := testcert.CA("AC de prueba")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err = testcert.Leaf(ca, "prueba", "20242643772", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca, leaf
+}
+
+func TestSignTRA(t *testing.T) {
+	ca, leaf := pairs(t)
+	signer, err := ParseSigner(leaf.CertPEM(), leaf.KeyPEM())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2020, 3, 16, 12, 0, 0, 0, time.UTC)
+	signer.Now = func() time.Time { return now }
+	for _, h := range []crypto.Hash{0, crypto.SHA1} {
+		signer.Hash = h
+		b64, err := signer.SignTRA(NewTRA("wsfe", now))
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(ca.Certificate)
+		s, err := cms.Verify(der, roots, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h == 0 {
+			h = crypto.SHA256
+		}
+		if s.Hash != h || !s.SigningTime.Equal(now) {
+			t.Errorf("Verify() = %v %v, want %v %v", s.Hash, s.SigningTime, h, now)
+		}
+		tra, err := ParseTRA(s.Content)
+		if err != nil || tra.Service != "wsfe" {
+			t.Errorf("ParseTRA() = %+v, %v", tra, err)
+		}
+	}
+	if _, err := signer.SignTRA(&TRA{}); err == nil {
+		t.Error("SignTRA() expected error")
+	}
+}
+
+func TestParseSigner(t *testing.T) {
+	_, leaf := pairs(t)
+	_, other := pairs(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(leaf.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseSigner(leaf.CertPEM(), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})); err != nil {
+		t.Errorf("ParseSigner(pkcs8) unexpected error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		cert    []byte
+		key     []byte
+		wantErr string
+	}{
+		{"otra clave", leaf.CertPEM(), other.KeyPEM(), "la clave privada no corresponde al certificado"},
+		{"sin certificado", leaf.KeyPEM(), leaf.KeyPEM(), "no se encontró un certificado en formato pem"},
+		{"sin clave", leaf.CertPEM(), nil, "no se encontró una clave privada en formato pem"},
+		{"tipo de clave", leaf.CertPEM(), leaf.CertPEM(), `tipo de clave privada pem no soportado: "CERTIFICATE"`},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseSigner(test.cert, test.key)
+			if err == nil || err.Error() != test.wantErr {
+				t.Errorf("ParseSigner() error = %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSigner(t *testing.T) {
+	_, leaf := pairs(t)
+	dir, err := ioutil.TempDir("", "wsaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(certFile, leaf.CertPEM(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, leaf.KeyPEM(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	signer, err := LoadSigner(certFile, keyFile)
+	if err != nil || !signer.Certificate.Equal(leaf.Certificate) {
+		t.Errorf("LoadSigner() = %v, %v", signer, err)
+	}
+	if _, err := LoadSigner(filepath.Join(dir, "otro.pem"), keyFile); err == nil {
+		t.Error("LoadSigner() expected error")
+	}
+	if _, err := LoadSigner(certFile, filepath.Join(dir, "otro.pem")); err == nil {
+		t.Error("LoadSigner() expected error")
+	}
+}
@@ -0,0 +1,82 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TA es un ticket de acceso (loginTicketResponse) emitido por WSAA.
+type TA struct {
+	XMLName xml.Name `xml:"loginTicketResponse"`
+	// Version es la versión del formato del ticket.
+	Version string `xml:"version,attr"`
+	// Source es el DN del emisor del ticket.
+	Source string `xml:"header>source"`
+	// Destination es el DN del certificado del solicitante.
+	Destination string `xml:"header>destination"`
+	// UniqueID es el identificador del ticket asignado por WSAA.
+	UniqueID uint32 `xml:"header>uniqueId"`
+	// GenerationTime es el instante a partir del cual es válido el ticket.
+	GenerationTime time.Time `xml:"header>generationTime"`
+	// ExpirationTime es el instante hasta el cual es válido el ticket.
+	ExpirationTime time.Time `xml:"header>expirationTime"`
+	// Token es el token que debe enviarse a los servicios de negocio.
+	Token string `xml:"credentials>token"`
+	// Sign es la firma del token que debe enviarse junto a él.
+	Sign string `xml:"credentials>sign"`
+}
+
+// ParseTA decodifica un ticket de acceso verificando que tenga credenciales
+// y vencimiento.
+func ParseTA(data []byte) (*TA, error) {
+	t := &TA{}
+	if err := xml.Unmarshal(data, t); err != nil {
+		return nil, errors.Wrap(err, "decodificando ticket de acceso")
+	}
+	switch {
+	case t.Token == "" || t.Sign == "":
+		return nil, errors.New("el ticket de acceso no tiene credenciales")
+	case t.ExpirationTime.IsZero():
+		return nil, errors.New("el ticket de acceso no tiene vencimiento")
+	}
+	return t, nil
+}
+
+// Marshal retorna la representación XML del ticket.
+func (t *TA) Marshal() ([]byte, error) {
+	bs, err := xml.Marshal(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "codificando ticket de acceso")
+	}
+	return append([]byte(xml.Header), bs...), nil
+}
+
+// ValidAt informa si el ticket sigue siendo válido en el instante now con
+// un margen de al menos margin antes de su vencimiento.
+func (t *TA) ValidAt(now time.Time, margin time.Duration) bool {
+	return !now.Before(t.GenerationTime) && now.Add(margin).Before(t.ExpirationTime)
+}
@@ -0,0 +1,117 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Tolerancia es el margen que NewTRA usa antes y después del instante de
+// generación para tolerar diferencias entre el reloj local y el de WSAA.
+const Tolerancia = 10 * time.Minute
+
+// TRA es un ticket de requerimiento de acceso (loginTicketRequest).
+type TRA struct {
+	XMLName xml.Name `xml:"loginTicketRequest"`
+	// Version es la versión del formato del ticket; si está vacía se usa
+	// "1.0".
+	Version string `xml:"version,attr"`
+	// Source es el DN del certificado del solicitante. Es opcional.
+	Source string `xml:"header>source,omitempty"`
+	// Destination es el DN del servicio de autenticación. Es opcional.
+	Destination string `xml:"header>destination,omitempty"`
+	// UniqueID identifica al ticket entre los generados por el solicitante.
+	UniqueID uint32 `xml:"header>uniqueId"`
+	// GenerationTime es el instante a partir del cual es válido el ticket.
+	GenerationTime time.Time `xml:"header>generationTime"`
+	// ExpirationTime es el instante hasta el cual es válido el ticket.
+	ExpirationTime time.Time `xml:"header>expirationTime"`
+	// Service es el nombre del servicio de negocio al que se solicita
+	// acceso, por ejemplo "wsfe".
+	Service string `xml:"service"`
+}
+
+// NewTRA construye un ticket de requerimiento de acceso al servicio service
+// generado en el instante now, válido desde Tolerancia antes hasta
+// Tolerancia después de now y con el identificador derivado de now.
+func NewTRA(service string, now time.Time) *TRA {
+	return &TRA{
+		Version:        "1.0",
+		UniqueID:       uint32(now.Unix()),
+		GenerationTime: now.Add(-Tolerancia),
+		ExpirationTime: now.Add(Tolerancia),
+		Service:        service,
+	}
+}
+
+// Validate verifica que el ticket tenga servicio y que su período de
+// validez no esté vacío.
+func (t *TRA) Validate() error {
+	switch {
+	case t.Service == "":
+		return errors.New("el ticket de requerimiento de acceso no tiene servicio")
+	case t.GenerationTime.IsZero() || t.ExpirationTime.IsZero():
+		return errors.New("el ticket de requerimiento de acceso no tiene período de validez")
+	case !t.ExpirationTime.After(t.GenerationTime):
+		return errors.Errorf("el vencimiento %s del ticket de requerimiento de acceso no es posterior a su generación %s",
+			t.ExpirationTime.Format(time.RFC3339), t.GenerationTime.Format(time.RFC3339))
+	default:
+		return nil
+	}
+}
+
+// Marshal valida el ticket y retorna su representación XML.
+//
+// Los instantes se escriben con precisión de segundos y con la diferencia
+// horaria de su zona.
+func (t *TRA) Marshal() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	c := *t
+	if c.Version == "" {
+		c.Version = "1.0"
+	}
+	c.GenerationTime = c.GenerationTime.Truncate(time.Second)
+	c.ExpirationTime = c.ExpirationTime.Truncate(time.Second)
+	bs, err := xml.Marshal(&c)
+	if err != nil {
+		return nil, errors.Wrap(err, "codificando ticket de requerimiento de acceso")
+	}
+	return append([]byte(xml.Header), bs...), nil
+}
+
+// ParseTRA decodifica y valida un ticket de requerimiento de acceso.
+func ParseTRA(data []byte) (*TRA, error) {
+	t := &TRA{}
+	if err := xml.Unmarshal(data, t); err != nil {
+		return nil, errors.Wrap(err, "decodificando ticket de requerimiento de acceso")
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
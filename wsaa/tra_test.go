@@ -0,0 +1,91 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaa
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTRAMarshal(t *testing.T) {
+	now := time.Date(2020, 3, 16, 12, 0, 0, 500, time.FixedZone("-03", -3*60*60))
+	tra := NewTRA("wsfe", now)
+	tra.Source = "SERIALNUMBER=CUIT 20242643772, CN=prueba"
+	bs, err := tra.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<loginTicketRequest version="1.0"><header><source>SERIALNUMBER=CUIT 20242643772, CN=prueba</source>` +
+		`<uniqueId>1584370800</uniqueId><generationTime>2020-03-16T11:50:00-03:00</generationTime>` +
+		`<expirationTime>2020-03-16T12:10:00-03:00</expirationTime></header><service>wsfe</service></loginTicketRequest>`
+	if string(bs) != want {
+		t.Errorf("Marshal() =\n%s\nwant\n%s", bs, want)
+	}
+	got, err := ParseTRA(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Service != "wsfe" || got.UniqueID != tra.UniqueID || got.Source != tra.Source ||
+		!got.GenerationTime.Equal(tra.GenerationTime.Truncate(time.Second)) || !got.ExpirationTime.Equal(tra.ExpirationTime.Truncate(time.Second)) {
+		t.Errorf("ParseTRA() = %+v, want %+v", got, tra)
+	}
+}
+
+func TestTRAValidate(t *testing.T) {
+	now := time.Date(2020, 3, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		tra     *TRA
+		wantErr string
+	}{
+		{"válido", NewTRA("wsfe", now), ""},
+		{"sin versión", &TRA{Service: "wsfe", GenerationTime: now, ExpirationTime: now.Add(time.Minute)}, ""},
+		{"sin servicio", NewTRA("", now), "el ticket de requerimiento de acceso no tiene servicio"},
+		{"sin fechas", &TRA{Service: "wsfe"}, "el ticket de requerimiento de acceso no tiene período de validez"},
+		{"vencido antes de generado", &TRA{Service: "wsfe", GenerationTime: now, ExpirationTime: now},
+			"el vencimiento 2020-03-16T12:00:00Z del ticket de requerimiento de acceso no es posterior a su generación 2020-03-16T12:00:00Z"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.tra.Marshal()
+			if test.wantErr == "" && err != nil {
+				t.Errorf("Marshal() unexpected error = %v", err)
+			}
+			if test.wantErr != "" && (err == nil || err.Error() != test.wantErr) {
+				t.Errorf("Marshal() error = %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseTRAErrors(t *testing.T) {
+	if _, err := ParseTRA([]byte("<loginTicketRequest>")); err == nil || !strings.HasPrefix(err.Error(), "decodificando ticket de requerimiento de acceso") {
+		t.Errorf("ParseTRA() error = %v", err)
+	}
+	if _, err := ParseTRA([]byte("<loginTicketRequest><service>wsfe</service></loginTicketRequest>")); err == nil {
+		t.Error("ParseTRA() expected error")
+	}
+}
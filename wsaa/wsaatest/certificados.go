@@ -0,0 +1,47 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaatest
+
+import (
+	"time"
+
+	"github.com/lalloni/afip/wsaa/internal/testcert"
+)
+
+// Pair es un certificado con su clave privada RSA.
+type Pair = testcert.Pair
+
+// NewCA genera un certificado autofirmado de autoridad de certificación con
+// el nombre name, válido desde una hora antes hasta un día después del
+// instante actual.
+func NewCA(name string) (*Pair, error) {
+	return testcert.CA(name)
+}
+
+// NewCertificate genera un certificado firmado por ca con la forma de los
+// emitidos por AFIP para computadores: el alias como CN y el CUIT en el
+// atributo serialNumber ("CUIT 20242643772"). Es válido entre notBefore y
+// notAfter.
+func NewCertificate(ca *Pair, alias string, cuit string, notBefore, notAfter time.Time) (*Pair, error) {
+	return testcert.Leaf(ca, alias, cuit, notBefore, notAfter)
+}
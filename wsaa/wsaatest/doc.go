@@ -0,0 +1,38 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package wsaatest provee un servidor WSAA local para pruebas de
+// integración que no pueden acceder al ambiente de homologación de AFIP.
+//
+// El servidor implementa la operación loginCms: verifica la firma CMS del
+// ticket de requerimiento de acceso contra las autoridades de certificación
+// configuradas, controla su período de validez y el servicio solicitado y
+// emite tickets de acceso con token y firma. También reproduce los errores
+// habituales de WSAA y permite inyectarlos explícitamente:
+//
+//	ca, _ := wsaatest.NewCA("AC de prueba")
+//	leaf, _ := wsaatest.NewCertificate(ca, "prueba", "20242643772", time.Now(), time.Now().Add(time.Hour))
+//	srv, _ := wsaatest.NewServer(ca.Certificate)
+//	defer srv.Close()
+//	signer, _ := wsaa.NewSigner(leaf.Certificate, leaf.Key)
+//	ta, err := srv.Client(signer).Login(ctx, "wsfe")
+package wsaatest
@@ -0,0 +1,364 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaatest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/lalloni/afip/cuit"
+	"github.com/lalloni/afip/token"
+	"github.com/lalloni/afip/wsaa"
+	"github.com/lalloni/afip/wsaa/internal/cms"
+	"github.com/lalloni/afip/wsaa/internal/testcert"
+)
+
+// CUIT es el CUIT de AFIP, que figura como entidad emisora de los tokens.
+const CUIT cuit.CUIT = 33693450239
+
+// DefaultLifetime es la duración predeterminada de los tickets emitidos.
+const DefaultLifetime = 12 * time.Hour
+
+// MaxTRALifetime es la máxima duración aceptada para el período de validez
+// de un ticket de requerimiento de acceso.
+const MaxTRALifetime = 24 * time.Hour
+
+// Server es un servidor WSAA local.
+//
+// Los campos de configuración pueden modificarse entre solicitudes pero no
+// mientras haya solicitudes en curso.
+type Server struct {
+	// URL es la dirección de la operación loginCms.
+	URL string
+	// Certificate es el certificado con el que el servidor firma los tokens
+	// emitidos. Su DN es la fuente (source) de los tickets de acceso.
+	Certificate *x509.Certificate
+	// Roots son las autoridades de certificación en las que confía el
+	// servidor para verificar a los solicitantes.
+	Roots *x509.CertPool
+	// Services son los servicios de negocio que conoce el servidor. Si está
+	// vacío se acepta cualquier servicio.
+	Services []string
+	// Lifetime es la duración de los tickets emitidos. Si es 0 se usa
+	// DefaultLifetime.
+	Lifetime time.Duration
+	// Now retorna el instante actual del servidor. Si es nil se usa
+	// time.Now.
+	Now func() time.Time
+
+	server  *httptest.Server
+	key     *rsa.PrivateKey
+	mu      sync.Mutex
+	faults  []*wsaa.Fault
+	tickets map[ticket]*wsaa.TA
+	issued  []*wsaa.TA
+	logins  int
+}
+
+type ticket struct {
+	dn      string
+	service string
+}
+
+// NewServer inicia un servidor WSAA local que confía en las autoridades de
+// certificación roots y acepta cualquier servicio. Debe cerrarse con Close.
+func NewServer(roots ...*x509.Certificate) (*Server, error) {
+	own, err := testcert.CA("wsaahomo")
+	if err != nil {
+		return nil, errors.Wrap(err, "generando certificado del servidor")
+	}
+	pool := x509.NewCertPool()
+	for _, root := range roots {
+		pool.AddCert(root)
+	}
+	s := &Server{
+		Certificate: own.Certificate,
+		Roots:       pool,
+		key:         own.Key,
+		tickets:     map[ticket]*wsaa.TA{},
+	}
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+	return s, nil
+}
+
+// Close detiene el servidor.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client construye un wsaa.Client que firma con signer y usa este servidor.
+func (s *Server) Client(signer *wsaa.Signer) *wsaa.Client {
+	c := wsaa.NewClient(signer, wsaa.Homologacion)
+	c.Endpoint = s.URL
+	c.HTTPClient = s.server.Client()
+	return c
+}
+
+// Fail hace que la próxima solicitud que reciba el servidor falle con el
+// código code (por ejemplo wsaa.CodeWSAAUnavailable) y el mensaje message.
+// Las fallas se aplican en el orden en que fueron agregadas.
+func (s *Server) Fail(code, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &wsaa.Fault{Code: code, Message: message})
+}
+
+// Logins retorna la cantidad de solicitudes recibidas, incluyendo las que
+// fallaron.
+func (s *Server) Logins() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logins
+}
+
+// Issued retorna los tickets de acceso emitidos en el orden en que fueron
+// emitidos.
+func (s *Server) Issued() []*wsaa.TA {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*wsaa.TA(nil), s.issued...)
+}
+
+// Revoke olvida los tickets de acceso vigentes, de modo que las siguientes
+// solicitudes no fallen con wsaa.CodeAlreadyAuthenticated.
+func (s *Server) Revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tickets = map[ticket]*wsaa.TA{}
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Server) lifetime() time.Duration {
+	if s.Lifetime != 0 {
+		return s.Lifetime
+	}
+	return DefaultLifetime
+}
+
+type request struct {
+	In0 *string `xml:"Body>loginCms>in0"`
+}
+
+// ServeHTTP implementa la operación loginCms.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logins++
+	if r.Method != http.MethodPost {
+		http.Error(w, "método no permitido", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req request
+	if err := xml.Unmarshal(data, &req); err != nil || req.In0 == nil {
+		fault(w, "soapenv", "Client", "solicitud SOAP inválida")
+		return
+	}
+	if len(s.faults) > 0 {
+		f := s.faults[0]
+		s.faults = s.faults[1:]
+		fault(w, "ns1", f.Code, f.Message)
+		return
+	}
+	ta, f := s.login(*req.In0)
+	if f != nil {
+		fault(w, "ns1", f.Code, f.Message)
+		return
+	}
+	bs, err := ta.Marshal()
+	if err != nil {
+		fault(w, "ns1", wsaa.CodeWSAAInternalError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml;charset=utf-8")
+	fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>`+
+		`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">`+
+		`<soapenv:Body><loginCmsResponse xmlns="http://wsaa.view.sua.dvadac.desein.afip.gov"><loginCmsReturn>`)
+	_ = xml.EscapeText(w, bs)
+	fmt.Fprint(w, `</loginCmsReturn></loginCmsResponse></soapenv:Body></soapenv:Envelope>`)
+}
+
+// login valida el mensaje CMS codificado en base64 in0 y emite el ticket de
+// acceso correspondiente.
+func (s *Server) login(in0 string) (*wsaa.TA, *wsaa.Fault) {
+	now := s.now()
+	der, err := base64.StdEncoding.DecodeString(in0)
+	if err != nil {
+		return nil, &wsaa.Fault{Code: wsaa.CodeCMSBad, Message: "No se ha podido decodificar el BASE64"}
+	}
+	signed, err := cms.Parse(der)
+	if err != nil {
+		return nil, &wsaa.Fault{Code: wsaa.CodeCMSBad, Message: "No se ha podido interpretar el CMS: " + err.Error()}
+	}
+	if f := s.verify(signed.Certificate, now); f != nil {
+		return nil, f
+	}
+	tra, err := wsaa.ParseTRA(signed.Content)
+	if err != nil {
+		return nil, &wsaa.Fault{Code: wsaa.CodeXMLBad, Message: "No se ha podido interpretar el XML: " + err.Error()}
+	}
+	switch {
+	case tra.Version != "" && tra.Version != "1.0":
+		return nil, &wsaa.Fault{Code: wsaa.CodeXMLVersionUnsupported, Message: "Version " + tra.Version + " no soportada"}
+	case now.Before(tra.GenerationTime):
+		return nil, &wsaa.Fault{Code: wsaa.CodeXMLGenerationInvalid, Message: "generationTime posee formato o dato inválido"}
+	case now.After(tra.ExpirationTime):
+		return nil, &wsaa.Fault{Code: wsaa.CodeXMLExpirationExpired, Message: "expirationTime expiró"}
+	case tra.ExpirationTime.Sub(tra.GenerationTime) > MaxTRALifetime:
+		return nil, &wsaa.Fault{Code: wsaa.CodeXMLExpirationInvalid, Message: "expirationTime posee formato o dato inválido"}
+	case !s.known(tra.Service):
+		return nil, &wsaa.Fault{Code: wsaa.CodeWSNNotFound, Message: "No se ha encontrado el WSN " + tra.Service}
+	}
+	n, err := wsaa.CertificateCUIT(signed.Certificate)
+	if err != nil {
+		return nil, &wsaa.Fault{Code: wsaa.CodeCMSCertInvalid, Message: err.Error()}
+	}
+	dn := signed.Certificate.Subject.String()
+	k := ticket{dn: dn, service: tra.Service}
+	if prev := s.tickets[k]; prev != nil && prev.ValidAt(now, 0) {
+		return nil, &wsaa.Fault{Code: wsaa.CodeAlreadyAuthenticated, Message: "El CEE ya posee un TA valido para el acceso al WSN solicitado"}
+	}
+	ta, err := s.issue(dn, n, tra.Service, now)
+	if err != nil {
+		return nil, &wsaa.Fault{Code: wsaa.CodeWSAAInternalError, Message: err.Error()}
+	}
+	s.tickets[k] = ta
+	s.issued = append(s.issued, ta)
+	return ta, nil
+}
+
+// verify verifica el certificado del firmante contra Roots.
+func (s *Server) verify(cert *x509.Certificate, now time.Time) *wsaa.Fault {
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:       s.Roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case x509.UnknownAuthorityError:
+		return &wsaa.Fault{Code: wsaa.CodeCMSCertUntrusted, Message: "Certificado no emitido por AC de confianza"}
+	case x509.CertificateInvalidError:
+		if e.Reason == x509.Expired {
+			return &wsaa.Fault{Code: wsaa.CodeCMSCertExpired, Message: "Certificado expirado"}
+		}
+	}
+	return &wsaa.Fault{Code: wsaa.CodeCMSCertInvalid, Message: "Certificado inválido: " + err.Error()}
+}
+
+func (s *Server) known(service string) bool {
+	if len(s.Services) == 0 {
+		return true
+	}
+	for _, known := range s.Services {
+		if known == service {
+			return true
+		}
+	}
+	return false
+}
+
+// issue construye un ticket de acceso para el solicitante con DN dn y CUIT
+// n con un token firmado con RSA-SHA1.
+func (s *Server) issue(dn string, n uint64, service string, now time.Time) (*wsaa.TA, error) {
+	var id [4]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, errors.Wrap(err, "generando identificador")
+	}
+	destination := pkix.Name{CommonName: service, Organization: []string{"AFIP"}, Country: []string{"AR"}, SerialNumber: "CUIT " + strconv.FormatUint(CUIT.Uint64(), 10)}
+	t := &token.Token{
+		Source:         s.Certificate.Subject.String(),
+		Destination:    destination.String(),
+		UniqueID:       uint32(id[0])<<24 | uint32(id[1])<<16 | uint32(id[2])<<8 | uint32(id[3]),
+		GenerationTime: now.Truncate(time.Second),
+		ExpirationTime: now.Add(s.lifetime()).Truncate(time.Second),
+		Operation:      "login",
+		Value:          token.Granted,
+		Login: token.Login{
+			Entity:     CUIT,
+			Service:    service,
+			UID:        dn,
+			AuthMethod: "cms",
+			RegMethod:  "22",
+			Relations:  []token.Relation{{Key: cuit.CUIT(n), RelType: 4}},
+		},
+	}
+	data, err := t.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "codificando token")
+	}
+	h := sha1.Sum(data)
+	sign, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA1, h[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "firmando token")
+	}
+	return &wsaa.TA{
+		Version:        "1.0",
+		Source:         t.Source,
+		Destination:    dn,
+		UniqueID:       t.UniqueID,
+		GenerationTime: t.GenerationTime,
+		ExpirationTime: t.ExpirationTime,
+		Token:          base64.StdEncoding.EncodeToString(data),
+		Sign:           base64.StdEncoding.EncodeToString(sign),
+	}, nil
+}
+
+// fault escribe un SOAP fault con el código code calificado con prefix.
+func fault(w http.ResponseWriter, prefix, code, message string) {
+	w.Header().Set("Content-Type", "text/xml;charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>`+
+		`<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">`+
+		`<soapenv:Body><soapenv:Fault><faultcode xmlns:ns1="http://xml.apache.org/axis/">%s:%s</faultcode><faultstring>`,
+		prefix, code)
+	_ = xml.EscapeText(w, []byte(message))
+	fmt.Fprint(w, `</faultstring></soapenv:Fault></soapenv:Body></soapenv:Envelope>`)
+}
@@ -0,0 +1,243 @@
+// MIT License
+//
+// Copyright (c) 2019 Pablo Ignacio Lalloni
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wsaatest
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lalloni/afip/token"
+	"github.com/lalloni/afip/wsaa"
+)
+
+type fixture struct {
+	ca     *Pair
+	leaf   *Pair
+	server *Server
+	client *wsaa.Client
+}
+
+func setup(t *testing.T) *fixture {
+	t.Helper()
+	ca, err := NewCA("AC de prueba")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := NewCertificate(ca, "prueba", "20242643772", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := NewServer(ca.Certificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fixture{ca: ca, leaf: leaf, server: server, client: client(t, server, leaf)}
+}
+
+func client(t *testing.T, server *Server, leaf *Pair) *wsaa.Client {
+	t.Helper()
+	signer, err := wsaa.NewSigner(leaf.Certificate, leaf.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server.Client(signer)
+}
+
+func TestServerLogin(t *testing.T) {
+	f := setup(t)
+	defer f.server.Close()
+	ta, err := f.client.Login(context.Background(), "wsfe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ta.Source != "CN=wsaahomo,O=AFIP,C=AR" || ta.Destination != "SERIALNUMBER=CUIT 20242643772,CN=prueba" {
+		t.Errorf("Login() = %+v", ta)
+	}
+	if d := ta.ExpirationTime.Sub(ta.GenerationTime); d != DefaultLifetime {
+		t.Errorf("duración = %v, want %v", d, DefaultLifetime)
+	}
+	got, err := token.Decode(ta.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Source != ta.Source || !got.ExpirationTime.Equal(ta.ExpirationTime) || got.Login.Service != "wsfe" ||
+		got.Login.Entity != CUIT || got.Login.CUIT != 20242643772 || !got.Represents(20242643772) {
+		t.Errorf("token = %+v", got)
+	}
+	if err := got.Validate(time.Now(), 0); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	sign, err := base64.StdEncoding.DecodeString(ta.Sign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha1.Sum(got.Raw)
+	if err := rsa.VerifyPKCS1v15(f.server.Certificate.PublicKey.(*rsa.PublicKey), crypto.SHA1, h[:], sign); err != nil {
+		t.Errorf("firma del token inválida: %v", err)
+	}
+	if issued := f.server.Issued(); len(issued) != 1 || issued[0].Token != ta.Token {
+		t.Errorf("Issued() = %v", issued)
+	}
+}
+
+func TestServerAlreadyAuthenticated(t *testing.T) {
+	f := setup(t)
+	defer f.server.Close()
+	f.server.Lifetime = 10 * time.Minute
+	if _, err := f.client.LoginCms(context.Background(), "wsfe"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := f.client.LoginCms(context.Background(), "wsfe")
+	if !stderrors.Is(err, wsaa.ErrAlreadyAuthenticated) {
+		t.Errorf("LoginCms() error = %v, want %v", err, wsaa.ErrAlreadyAuthenticated)
+	}
+	// Otro servicio no está afectado.
+	if _, err := f.client.LoginCms(context.Background(), "ws_sr_padron_a5"); err != nil {
+		t.Errorf("LoginCms() error = %v", err)
+	}
+	f.server.Revoke()
+	if _, err := f.client.LoginCms(context.Background(), "wsfe"); err != nil {
+		t.Errorf("LoginCms() error = %v", err)
+	}
+	// Vencido el ticket anterior se emite uno nuevo.
+	f.server.Now = func() time.Time { return time.Now().Add(11 * time.Minute) }
+	f.client.Now = f.server.Now
+	if _, err := f.client.LoginCms(context.Background(), "wsfe"); err != nil {
+		t.Errorf("LoginCms() error = %v", err)
+	}
+	if f.server.Logins() != 5 {
+		t.Errorf("Logins() = %d, want 5", f.server.Logins())
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	f := setup(t)
+	defer f.server.Close()
+	other, err := NewCA("otra AC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrusted, err := NewCertificate(other, "prueba", "20242643772", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := NewCertificate(f.ca, "prueba", "20242643772", time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nocuit, err := NewCertificate(f.ca, "prueba", "20242643773", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	late := client(t, f.server, f.leaf)
+	late.Now = func() time.Time { return time.Now().Add(-wsaa.Tolerancia - time.Minute) }
+	early := client(t, f.server, f.leaf)
+	early.Now = func() time.Time { return time.Now().Add(wsaa.Tolerancia + time.Minute) }
+	f.server.Services = []string{"wsfe"}
+	tests := []struct {
+		name    string
+		client  *wsaa.Client
+		service string
+		code    string
+	}{
+		{"untrusted", client(t, f.server, untrusted), "wsfe", wsaa.CodeCMSCertUntrusted},
+		{"cert expired", client(t, f.server, expired), "wsfe", wsaa.CodeCMSCertExpired},
+		{"no cuit", client(t, f.server, nocuit), "wsfe", wsaa.CodeCMSCertInvalid},
+		{"tra expired", late, "wsfe", wsaa.CodeXMLExpirationExpired},
+		{"tra future", early, "wsfe", wsaa.CodeXMLGenerationInvalid},
+		{"service", f.client, "wsmtxca", wsaa.CodeWSNNotFound},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.client.LoginCms(context.Background(), test.service)
+			var fault *wsaa.Fault
+			if !stderrors.As(err, &fault) || fault.Code != test.code || fault.Message == "" {
+				t.Errorf("LoginCms() error = %v, want code %q", err, test.code)
+			}
+		})
+	}
+	if len(f.server.Issued()) != 0 {
+		t.Errorf("Issued() = %v, want none", f.server.Issued())
+	}
+}
+
+func TestServerFail(t *testing.T) {
+	f := setup(t)
+	defer f.server.Close()
+	f.server.Fail(wsaa.CodeWSAAUnavailable, "servicio no disponible")
+	f.server.Fail(wsaa.CodeNotAuthorized, "no autorizado")
+	_, err := f.client.LoginCms(context.Background(), "wsfe")
+	if err == nil || err.Error() != "error de wsaa wsaa.unavailable: servicio no disponible" {
+		t.Errorf("LoginCms() error = %v", err)
+	}
+	if _, err = f.client.LoginCms(context.Background(), "wsfe"); !stderrors.Is(err, wsaa.ErrNotAuthorized) {
+		t.Errorf("LoginCms() error = %v, want %v", err, wsaa.ErrNotAuthorized)
+	}
+	if _, err = f.client.LoginCms(context.Background(), "wsfe"); err != nil {
+		t.Errorf("LoginCms() error = %v", err)
+	}
+}
+
+func TestServerBadRequest(t *testing.T) {
+	f := setup(t)
+	defer f.server.Close()
+	for _, body := range []string{"basura", "<Envelope><Body><loginCms><in0>no es base64</in0></loginCms></Body></Envelope>", "<Envelope><Body><loginCms><in0>AAAA</in0></loginCms></Body></Envelope>"} {
+		res, err := http.Post(f.server.URL, "text/xml", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusInternalServerError {
+			t.Errorf("POST %q = %d, want %d", body, res.StatusCode, http.StatusInternalServerError)
+		}
+	}
+	res, err := http.Get(f.server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET = %d, want %d", res.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func ExampleServer() {
+	ca, _ := NewCA("AC de prueba")
+	leaf, _ := NewCertificate(ca, "prueba", "20242643772", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	srv, _ := NewServer(ca.Certificate)
+	defer srv.Close()
+	signer, _ := wsaa.NewSigner(leaf.Certificate, leaf.Key)
+	ta, err := srv.Client(signer).Login(context.Background(), "wsfe")
+	fmt.Println(ta.Source, err)
+	// Output: CN=wsaahomo,O=AFIP,C=AR <nil>
+}